@@ -0,0 +1,81 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func newAPIKey(k *ent.APIKey) APIKey {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return APIKey{
+		Id:         k.ID,
+		Owner:      k.Owner,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func (handler *Handler) APIKeysList(ctx echo.Context) error {
+	slog.DebugContext(ctx.Request().Context(), "APIKeysList")
+	keys, err := handler.store.ListAPIKeys(ctx.Request().Context())
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	resp := APIKeyList{Apikeys: make([]APIKey, 0, len(keys))}
+	for _, k := range keys {
+		resp.Apikeys = append(resp.Apikeys, newAPIKey(k))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (handler *Handler) APIKeysCreate(ctx echo.Context) error {
+	var input APIKeysCreateJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return respondBindError(ctx, err)
+	}
+	if input.Owner == "" {
+		return respondInvalid(ctx, problem.FieldError{Field: "owner", Detail: "must not be empty"})
+	}
+	slog.DebugContext(ctx.Request().Context(), "APIKeysCreate", "owner", input.Owner, "scopes", input.Scopes)
+	fields := store.APIKeyFields{
+		Owner:     input.Owner,
+		Scopes:    make([]string, 0, len(input.Scopes)),
+		ExpiresAt: input.ExpiresAt,
+	}
+	for _, scope := range input.Scopes {
+		fields.Scopes = append(fields.Scopes, string(scope))
+	}
+	k, secret, err := handler.store.CreateAPIKey(ctx.Request().Context(), fields)
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	created := newAPIKey(k)
+	return ctx.JSON(http.StatusCreated, APIKeyCreated{
+		Id:        created.Id,
+		Owner:     created.Owner,
+		Scopes:    created.Scopes,
+		CreatedAt: created.CreatedAt,
+		ExpiresAt: created.ExpiresAt,
+		Key:       secret,
+	})
+}
+
+func (handler *Handler) APIKeysRevoke(ctx echo.Context, id openapi_types.UUID) error {
+	slog.DebugContext(ctx.Request().Context(), "APIKeysRevoke", "id", id)
+	if err := handler.store.RevokeAPIKey(ctx.Request().Context(), id); err != nil {
+		return respondStoreError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/problem"
 	"github.com/keith-cullen/microservice/store"
 	"github.com/labstack/echo/v4"
 )
@@ -21,12 +23,16 @@ func NewHandler(store *store.Store) *Handler {
 	return &Handler{store: store}
 }
 
+// MarkDeprecated marks a response as coming from a deprecated endpoint
+// The successor link points to the endpoint that replaces it
+func MarkDeprecated(ctx echo.Context, successor string) {
+	ctx.Response().Header().Set("Deprecation", "true")
+	ctx.Response().Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+}
+
 func (handler *Handler) AppDefault(ctx echo.Context) error {
-	log.Print("Default()")
-	resp := &AppResponse{
-		Message: "404 Not Found",
-	}
-	return ctx.JSON(http.StatusNotFound, resp)
+	slog.DebugContext(ctx.Request().Context(), "Default")
+	return respondError(ctx, http.StatusNotFound, "")
 }
 
 func (handler *Handler) AppGet(ctx echo.Context, params AppGetParams) error {
@@ -36,18 +42,13 @@ func (handler *Handler) AppGet(ctx echo.Context, params AppGetParams) error {
 	} else {
 		name = *params.Name
 	}
-	log.Printf("AppGet(name: %q)", name)
+	slog.DebugContext(ctx.Request().Context(), "AppGet", "name", name)
+	MarkDeprecated(ctx, "/v1/things")
 	if name == "" {
-		resp := &AppResponse{
-			Message: "400 Bad Request",
-		}
-		return ctx.JSON(http.StatusBadRequest, resp)
+		return respondInvalid(ctx, problem.FieldError{Field: "name", Detail: "is required"})
 	}
 	if _, err := handler.store.GetThing(ctx.Request().Context(), name); err != nil {
-		resp := &AppResponse{
-			Message: "404 Not Found",
-		}
-		return ctx.JSON(http.StatusNotFound, resp)
+		return respondStoreError(ctx, err)
 	}
 	resp := &AppResponse{
 		Message: fmt.Sprintf("Hello, %s", name),
@@ -62,18 +63,13 @@ func (handler *Handler) AppSet(ctx echo.Context, params AppSetParams) error {
 	} else {
 		name = *params.Name
 	}
-	log.Printf("AppSet(name: %q)", name)
+	slog.DebugContext(ctx.Request().Context(), "AppSet", "name", name, "identity", auth.FromContext(ctx.Request().Context()).String())
+	MarkDeprecated(ctx, "/v1/things")
 	if name == "" {
-		resp := &AppResponse{
-			Message: "400 Bad Request",
-		}
-		return ctx.JSON(http.StatusBadRequest, resp)
+		return respondInvalid(ctx, problem.FieldError{Field: "name", Detail: "is required"})
 	}
 	if err := handler.store.SetThing(ctx.Request().Context(), name); err != nil {
-		resp := &AppResponse{
-			Message: "500 Internal Server Error",
-		}
-		return ctx.JSON(http.StatusInternalServerError, resp)
+		return respondStoreError(ctx, err)
 	}
 	resp := &AppResponse{
 		Message: fmt.Sprintf("Hello, %s", name),
@@ -7,40 +7,147 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// GoogleProtobufAny Contains an arbitrary serialized message along with a @type that describes the type of the serialized message.
-type GoogleProtobufAny struct {
-	// Type The type of the serialized message.
-	Type                 *string                `json:"@type,omitempty"`
-	AdditionalProperties map[string]interface{} `json:"-"`
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for APIKeyInputScopes.
+const (
+	ApikeysAdmin APIKeyInputScopes = "apikeys:admin"
+	ThingsRead   APIKeyInputScopes = "things:read"
+	ThingsWrite  APIKeyInputScopes = "things:write"
+)
+
+// Defines values for ThingsListParamsSort.
+const (
+	CreatedAt      ThingsListParamsSort = "created_at"
+	Id             ThingsListParamsSort = "id"
+	MinusCreatedAt ThingsListParamsSort = "-created_at"
+	MinusId        ThingsListParamsSort = "-id"
+	MinusName      ThingsListParamsSort = "-name"
+	Name           ThingsListParamsSort = "name"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	ExpiresAt  *time.Time         `json:"expires_at,omitempty"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+	Owner      string             `json:"owner"`
+	RevokedAt  *time.Time         `json:"revoked_at,omitempty"`
+	Scopes     []string           `json:"scopes"`
+}
+
+// APIKeyCreated defines model for APIKeyCreated.
+type APIKeyCreated struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// Key The secret to send in the X-API-Key header, it cannot be retrieved later
+	Key        string     `json:"key"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Owner      string     `json:"owner"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	Scopes     []string   `json:"scopes"`
+}
+
+// APIKeyInput defines model for APIKeyInput.
+type APIKeyInput struct {
+	ExpiresAt *time.Time          `json:"expires_at,omitempty"`
+	Owner     string              `json:"owner"`
+	Scopes    []APIKeyInputScopes `json:"scopes"`
+}
+
+// APIKeyInputScopes defines model for APIKeyInput.Scopes.
+type APIKeyInputScopes string
+
+// APIKeyList defines model for APIKeyList.
+type APIKeyList struct {
+	Apikeys []APIKey `json:"apikeys"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Detail string `json:"detail"`
+
+	// Field Name of the query parameter, path parameter or request body field
+	Field string `json:"field"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Detail Explanation of this occurrence of the problem, absent for server errors
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors The invalid fields of a request that failed validation
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestId ID of the request, as sent in the X-Request-ID header, to find it in the logs
+	RequestId *string `json:"request_id,omitempty"`
+
+	// Status HTTP status code of the response
+	Status int `json:"status"`
+
+	// Title Summary of the problem type, the reason phrase of the status
+	Title string `json:"title"`
+
+	// Type URI reference of the problem type, about:blank if the status describes the problem
+	Type string `json:"type"`
 }
 
 // Resp defines model for Resp.
 type Resp struct {
-	Value *string `json:"value,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// Thing defines model for Thing.
+type Thing struct {
+	Attributes  map[string]interface{} `json:"attributes"`
+	CreatedAt   time.Time              `json:"created_at"`
+	Description string                 `json:"description"`
+	Id          openapi_types.UUID     `json:"id"`
+	Name        string                 `json:"name"`
+	UpdatedAt   time.Time              `json:"updated_at"`
 }
 
-// Status The `Status` type defines a logical error model that is suitable for different programming environments, including REST APIs and RPC APIs. It is used by [gRPC](https://github.com/grpc). Each `Status` message contains three pieces of data: error code, error message, and error details. You can find out more about this error model and how to work with it in the [API Design Guide](https://cloud.google.com/apis/design/errors).
-type Status struct {
-	// Code The status code, which should be an enum value of [google.rpc.Code][google.rpc.Code].
-	Code *int32 `json:"code,omitempty"`
+// ThingInput defines model for ThingInput.
+type ThingInput struct {
+	Attributes  *map[string]interface{} `json:"attributes,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	Name        string                  `json:"name"`
+}
 
-	// Details A list of messages that carry the error details.  There is a common set of message types for APIs to use.
-	Details *[]GoogleProtobufAny `json:"details,omitempty"`
+// ThingList defines model for ThingList.
+type ThingList struct {
+	// NextCursor Cursor for the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Things     []Thing `json:"things"`
+}
 
-	// Message A developer-facing error message, which should be in English. Any user-facing error message should be localized and sent in the [google.rpc.Status.details][google.rpc.Status.details] field, or localized by the client.
-	Message *string `json:"message,omitempty"`
+// ThingPatch Only the fields that are present are changed, an empty attributes object clears the attributes
+type ThingPatch struct {
+	Attributes  *map[string]interface{} `json:"attributes,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	Name        *string                 `json:"name,omitempty"`
 }
 
 // AppGetParams defines parameters for AppGet.
@@ -53,85 +160,74 @@ type AppSetParams struct {
 	Name *string `form:"name,omitempty" json:"name,omitempty"`
 }
 
-// Getter for additional properties for GoogleProtobufAny. Returns the specified
-// element and whether it was found
-func (a GoogleProtobufAny) Get(fieldName string) (value interface{}, found bool) {
-	if a.AdditionalProperties != nil {
-		value, found = a.AdditionalProperties[fieldName]
-	}
-	return
-}
+// ThingsListParams defines parameters for ThingsList.
+type ThingsListParams struct {
+	// Prefix Only list things whose name starts with this prefix
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
 
-// Setter for additional properties for GoogleProtobufAny
-func (a *GoogleProtobufAny) Set(fieldName string, value interface{}) {
-	if a.AdditionalProperties == nil {
-		a.AdditionalProperties = make(map[string]interface{})
-	}
-	a.AdditionalProperties[fieldName] = value
-}
+	// Contains Only list things whose name contains this substring
+	Contains *string `form:"contains,omitempty" json:"contains,omitempty"`
 
-// Override default JSON handling for GoogleProtobufAny to handle AdditionalProperties
-func (a *GoogleProtobufAny) UnmarshalJSON(b []byte) error {
-	object := make(map[string]json.RawMessage)
-	err := json.Unmarshal(b, &object)
-	if err != nil {
-		return err
-	}
+	// Sort Sort order, a leading '-' sorts in descending order
+	Sort *ThingsListParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
 
-	if raw, found := object["@type"]; found {
-		err = json.Unmarshal(raw, &a.Type)
-		if err != nil {
-			return fmt.Errorf("error reading '@type': %w", err)
-		}
-		delete(object, "@type")
-	}
+	// Limit Maximum number of things in the page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	if len(object) != 0 {
-		a.AdditionalProperties = make(map[string]interface{})
-		for fieldName, fieldBuf := range object {
-			var fieldVal interface{}
-			err := json.Unmarshal(fieldBuf, &fieldVal)
-			if err != nil {
-				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
-			}
-			a.AdditionalProperties[fieldName] = fieldVal
-		}
-	}
-	return nil
+	// Cursor Opaque cursor taken from the next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
-// Override default JSON handling for GoogleProtobufAny to handle AdditionalProperties
-func (a GoogleProtobufAny) MarshalJSON() ([]byte, error) {
-	var err error
-	object := make(map[string]json.RawMessage)
+// ThingsListParamsSort defines parameters for ThingsList.
+type ThingsListParamsSort string
 
-	if a.Type != nil {
-		object["@type"], err = json.Marshal(a.Type)
-		if err != nil {
-			return nil, fmt.Errorf("error marshaling '@type': %w", err)
-		}
-	}
+// APIKeysCreateJSONRequestBody defines body for APIKeysCreate for application/json ContentType.
+type APIKeysCreateJSONRequestBody = APIKeyInput
 
-	for fieldName, field := range a.AdditionalProperties {
-		object[fieldName], err = json.Marshal(field)
-		if err != nil {
-			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
-		}
-	}
-	return json.Marshal(object)
-}
+// ThingsCreateJSONRequestBody defines body for ThingsCreate for application/json ContentType.
+type ThingsCreateJSONRequestBody = ThingInput
+
+// ThingsUpdateJSONRequestBody defines body for ThingsUpdate for application/json ContentType.
+type ThingsUpdateJSONRequestBody = ThingPatch
+
+// ThingsReplaceJSONRequestBody defines body for ThingsReplace for application/json ContentType.
+type ThingsReplaceJSONRequestBody = ThingInput
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
-	// (GET /) (POST /)
-	AppDefault(ctx echo.Context) error
+	// (GET /v1/admin/apikeys)
+	APIKeysList(ctx echo.Context) error
+
+	// (POST /v1/admin/apikeys)
+	APIKeysCreate(ctx echo.Context) error
+
+	// (DELETE /v1/admin/apikeys/{id})
+	APIKeysRevoke(ctx echo.Context, id openapi_types.UUID) error
 
 	// (GET /v1/get)
 	AppGet(ctx echo.Context, params AppGetParams) error
 
 	// (POST /v1/set)
 	AppSet(ctx echo.Context, params AppSetParams) error
+
+	// (GET /v1/things)
+	ThingsList(ctx echo.Context, params ThingsListParams) error
+
+	// (POST /v1/things)
+	ThingsCreate(ctx echo.Context) error
+
+	// (DELETE /v1/things/{id})
+	ThingsDelete(ctx echo.Context, id openapi_types.UUID) error
+
+	// (GET /v1/things/{id})
+	ThingsGet(ctx echo.Context, id openapi_types.UUID) error
+
+	// (PATCH /v1/things/{id})
+	ThingsUpdate(ctx echo.Context, id openapi_types.UUID) error
+
+	// (PUT /v1/things/{id})
+	ThingsReplace(ctx echo.Context, id openapi_types.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -139,12 +235,49 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
-// AppDefault converts echo context to params.
-func (w *ServerInterfaceWrapper) AppDefault(ctx echo.Context) error {
+// APIKeysList converts echo context to params.
+func (w *ServerInterfaceWrapper) APIKeysList(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{"apikeys:admin"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.AppDefault(ctx)
+	err = w.Handler.APIKeysList(ctx)
+	return err
+}
+
+// APIKeysCreate converts echo context to params.
+func (w *ServerInterfaceWrapper) APIKeysCreate(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"apikeys:admin"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.APIKeysCreate(ctx)
+	return err
+}
+
+// APIKeysRevoke converts echo context to params.
+func (w *ServerInterfaceWrapper) APIKeysRevoke(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"apikeys:admin"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.APIKeysRevoke(ctx, id)
 	return err
 }
 
@@ -152,6 +285,10 @@ func (w *ServerInterfaceWrapper) AppDefault(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) AppGet(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{"things:read"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params AppGetParams
 	// ------------- Optional query parameter "name" -------------
@@ -170,6 +307,10 @@ func (w *ServerInterfaceWrapper) AppGet(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) AppSet(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{"things:write"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params AppSetParams
 	// ------------- Optional query parameter "name" -------------
@@ -184,6 +325,149 @@ func (w *ServerInterfaceWrapper) AppSet(ctx echo.Context) error {
 	return err
 }
 
+// ThingsList converts echo context to params.
+func (w *ServerInterfaceWrapper) ThingsList(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"things:read"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ThingsListParams
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", ctx.QueryParams(), &params.Prefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter prefix: %s", err))
+	}
+
+	// ------------- Optional query parameter "contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contains", ctx.QueryParams(), &params.Contains)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter contains: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ThingsList(ctx, params)
+	return err
+}
+
+// ThingsCreate converts echo context to params.
+func (w *ServerInterfaceWrapper) ThingsCreate(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"things:write"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ThingsCreate(ctx)
+	return err
+}
+
+// ThingsDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) ThingsDelete(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"things:write"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ThingsDelete(ctx, id)
+	return err
+}
+
+// ThingsGet converts echo context to params.
+func (w *ServerInterfaceWrapper) ThingsGet(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"things:read"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ThingsGet(ctx, id)
+	return err
+}
+
+// ThingsUpdate converts echo context to params.
+func (w *ServerInterfaceWrapper) ThingsUpdate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"things:write"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ThingsUpdate(ctx, id)
+	return err
+}
+
+// ThingsReplace converts echo context to params.
+func (w *ServerInterfaceWrapper) ThingsReplace(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"things:write"})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ThingsReplace(ctx, id)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -212,31 +496,57 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
-	router.GET(baseURL+"/*", wrapper.AppDefault)
-	router.POST(baseURL+"/*", wrapper.AppDefault)
+	router.GET(baseURL+"/v1/admin/apikeys", wrapper.APIKeysList)
+	router.POST(baseURL+"/v1/admin/apikeys", wrapper.APIKeysCreate)
+	router.DELETE(baseURL+"/v1/admin/apikeys/:id", wrapper.APIKeysRevoke)
 	router.GET(baseURL+"/v1/get", wrapper.AppGet)
 	router.POST(baseURL+"/v1/set", wrapper.AppSet)
+	router.GET(baseURL+"/v1/things", wrapper.ThingsList)
+	router.POST(baseURL+"/v1/things", wrapper.ThingsCreate)
+	router.DELETE(baseURL+"/v1/things/:id", wrapper.ThingsDelete)
+	router.GET(baseURL+"/v1/things/:id", wrapper.ThingsGet)
+	router.PATCH(baseURL+"/v1/things/:id", wrapper.ThingsUpdate)
+	router.PUT(baseURL+"/v1/things/:id", wrapper.ThingsReplace)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+yVzW7jNhDHX2XA9tAFVCm7e/OpRjYIgh5qJLkUgYGlyJE0W4qjkqMEruF3L0jJtR17",
-	"2xx6aIG9xPQw8/WfH8dbZbgf2KOXqBZbFU2Hvc7HW+bW4SqwcD02S79JRm0tCbHXbhV4wCCEUS0kjFgo",
-	"i9EEGtK1Wqhr9qLJR9AedKhJgg4biBhIO/oDLfQYo24RtGPfwgtJBxp+ks2AIJ0WmMLVGEE6hGznJp/P",
-	"g5SqUMNRQVuVA6XDaVWPbwo1+aoogXyrdru/LFx/QSNqV6h7jEMKf5r1WbsxZ31DhAfRMsbLJX6eLj9P",
-	"tVpsyGMEDY5bMtoBhsABerboJrEoQhxJdO0QGg5gqWkwoBcYArdB9z35FtA/U2Dfp2kXQN640Sb7/c3D",
-	"IyxXd2lYFu5X1/lLCXc58BjRQr2Bp/Z+db3+oRMZ4qKqWpJurEvDfdWGwbwr4Uab7lD6fr5mD4J0AREG",
-	"QoMx6W+16MXcimGLxb6tybHIxUwmi6LJxRJ+5RGM9tCQt8CjQM8BQdfpKB3FE2WSf8cvIAwvHH6bGCMB",
-	"8nn2T8vVHXzCSK2H25EsHnozjkdbtvkF5A71QLGy+X+rnCK+O2cuNXF5njFrMnf50pHpIHY8Ogs1pgeC",
-	"fuwhw5OEeZoTh8GU12xxfWZIuRsOvRa1UOTl44cDteQFWwwJsVm285qW4ChKyjWLHSeMjA5hk8V5pTs8",
-	"dhgw0aDBcN+zh4jHATKpMbOXQRJO3KQ6SbDPJXwfsFEL9V11WDnVvG+q82VzeDI6BJ2/z6kutWPxGV2a",
-	"xY+NNhn1U5Rea04ebnzrKHYlLP0m1XrZ9cjHsZlXRQIrpse1J+loPhP+5azc+m+uoCF0tgAOR6HrSX7j",
-	"CL28aRclE/mG89ohceluOQxpDKpQzxjiJNJVeVW+TzLygF4PpBbqY3lVfkwYa+nyiKr0p0VJH0lNnQS+",
-	"s1PET9jo0YkqVMA4sI8T9R+urib4vaDPnnoYHJnsW32JKfn+h+WfMMhbNXd0OuBffp5wngr4t7LNG/hC",
-	"vrnVGYV9u5P6uo1q8ZQEUetdoQaO3+R6q1y7QlXP76uZsK+BdouSoQy6R8GQ/LeKUqLfRwwbVSiv+4R5",
-	"/iiOyn/9WNbfxD8TP06qf5Xbh/+M+nO8mtmh9v8vrQ+W7V6vdLNb7/4MAAD//7bCtDNzCwAA",
+	"H4sIAAAAAAAC/+xYW2/juPX/Kgf8/4F9qJI4e+kWBvqQzmU3O9NOkGSxBZJgQEvHNjcSySEpJ0bg714c",
+	"kpIli3bi6WYmRftkS6TO/fzO5YHlqtJKonSWjR+YQauVtOgfzoyalFjR31xJh9LRX651KXLuhJJHOtz4",
+	"0+9WSTqz+RwrTv/+3+CUjdn/Ha3pH4VTe9TQXa1WGSvQ5kZoIsfG7I0xykAjRQYcIgso0HFRWlCT3zF3",
+	"wC0UOBUSC5gs4fztK/jxL6Mfr+UJGPxUo3Xg5tzB1H+z4KUovMQgLHD4fjSCu7myCEj8LJTCf4AgpL8L",
+	"U4FlYa8lIxGj3KTWydnpO1zSP22URuNEMFVukDssPnJvoqkyFf1jBXd44ESFLGNuqZGNmXVGyBlbZQzv",
+	"tTBo9/pGFL27dS2K1LWSW/extnsKpO4kGro+ODG4ULd7UrO50sE4wmFlk3TjC24MX3pTk/OEwYKNr5hX",
+	"LcjUUsu6lr5pCYSgIIrBQa/CJR+uZflhysZXu0MyfMZW2aZjb4O3+1F6OUewmBt04BRYlAUI6ePnnwcn",
+	"Z6cH73AJc+QFmgyEg5xLqRxMEAw6I3CBBZTceb02TLJhAuI+1PKm1fNU6toNo/FzIqt1fiXke5QzN2fj",
+	"46e5FWVdkbRuLuTMjg1yH5Th6c4IRwy5Fre4tGNeVEKymwTlncGwEQfbXf9e2IRFIvee2E+Kh91SNWRT",
+	"4rwlCPF4NhQngFkyJTzyDEPuH7xCUFMfZJ9qNEvQ3PAKHcWY5m6+fgZlWhCcqGIZwOzRWGtuRdlSKnUK",
+	"wjZ9NuD8XpdcBuT1wgsLKs9rY1DmrT4R4jPgE4vSwVQZsGgWaCI+J9EznCRzs4/ixIYPywIWnbrAsqfF",
+	"Rcepg9jImJDWcZnjUKoz8lBUN0qSUioefRSJCDh9vUEgoyLoDdaCz3k4OTh93eKPUzAVBFDttVLNkha1",
+	"jrs6YdGfLy/PIBxCrgpcixGq9JqWkA5nGEwjXJmww0VdVdwsNzwP9H0WiXKrJOi54bblFCXbihnEZcrr",
+	"0pEzJqp240nJ5S3b7C5+PT8Fg1NMRV+UofM5iC53CKQmaLtfPZpV/rQxR2vjVHKdo9XDzKrQWj7DBFSs",
+	"EjQuCXOHRLhzRkxqF5+KQpBBeHnWueVMjQmKn9PY9Iz+8NlNjOQVJr+vdbGnTKnOwpPvC5t1LdXTvcf0",
+	"Zpvlt5Tjf8P8j5mysdHOor2hvf9mqw7p+inx3n3Ma2NDNeun1Sv/3sM2pQbdBc1n2AK6isDDbThIZjIx",
+	"f3qBvpw33+2qz5HoVmXPuMvnQ4U+yHLpJY4FxNcMbijr0StE//M5lzMsMuASsNJuCWs/N1NKXiI3ATB6",
+	"kfXi4mODMFUDzGsj3PKCDN42Ue9weVK7hMlOJJycncItLiGmDc1lZx8uLuFocXzku76j2C5lcDcX+Rxm",
+	"hksXrBPaOpiJBUq4m6OkenXHbUOMUXFlYxaqWpO9Y9b22+uQClKS8hPkBk0jb3h626DFL79dDurDCfzy",
+	"2yVYMaOx8k64Ofx88e0Pf87gnH6or3rj/4TZ0ctML22uIS+5qNYatY0w1d/w699dSy4L6LbGdCGEEhmi",
+	"ykBR8PE8R01GbGpQaIcEVWA5FbPaNBI6dYsSFmjENE7l5ARi1G24u7auuOQzrHxiThuv0awbJ11k42is",
+	"tVHnzukwrws5VT7UQoFnJ1oTDZaxBRob7Dg6HB0e+6FCI8nBxuy7w9HhdxT53M19MA2igl7O0GMPhb1X",
+	"5bQgDr4Xtx6Ysv6G4tvRaMd2Yr+tRGd8SCwmPrwL6RbbjDSpVrbOimOdSX4I7cbk1eZQRONnN8mublYE",
+	"XZxw8aqxA11jWtntlgrTL2vbyb+pYvkHmylUulUfbwmjVgMPHf/BrJvZPuGkeBQ6SYIiYUM6GXS1oaT2",
+	"LbCw69715Th1lQ1z4uhBFKsAtiU63Orxc7+fGWbH94lBUsGr6IwXFdDN9Go9VQ/2hBVrqPdNWz/Ysk7g",
+	"PNJOrm6ifSPExJ8CtcGcuzXFvrVet+cZ1BbhpzehngUE9+4BIa1DXhyybNM5Wv+EjqWV80P8Wjv/09Vn",
+	"IP8zQp8fPb4Y6HXXRI9FiNbrzLDBZQ307eu6thcJ/He67eJ/btvmtrDP28dv684+Wd59I95U9w2TJ9ry",
+	"uKr3Lgx9GPmAhnTjbGyJCOG1wam4Z1nSbe3hdsdl+3And3IhbWBt60kkk+be3N6P/4UyDpTxSx0OJfJC",
+	"yBl8c/ANWEWqC+lXFCj9e39xC3+63+Pdhkp/5G32ur2XB72nGP8H8dcj74EoEvvdoUJ/5/eiqiuQdTVB",
+	"E/eDITPDiiVMiikNSlGJLSr8MMpYFSiz8fFoRI9CxsfhpirhZ80/1Qhh2AXHqb2eGlW1s22cg9f7I1wI",
+	"Vdtd8oYvvhpQrAf7NFpkca7yjN8LeTtsG+gtjSq9AT+km8Hyr9d+Q3DNdqq4+rrF5DIuA7a2z+HCs3bP",
+	"nTXRF26e48Jka9O8EQQqMOnT/4IOfVKZaT3aqzSPNs3hs9fh9Ov3zJ+lcbarmIau83nh5EX2i90Uf/aB",
+	"gnjE9WHKCb/6hfFz4kjYXj4JR/7Tnb9vcsQ9fMov56hLnr8UgP/vcsxq1b58aNKQZoVV1j7Gu503zZpg",
+	"dbP61wBIYTOSryQAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func newThing(t *ent.Thing) Thing {
+	attributes := t.Attributes
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return Thing{
+		Id:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Attributes:  attributes,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// Convert the optional fields of a request body to the fields of a thing
+func newThingFields(name string, description *string, attributes *map[string]any) store.ThingFields {
+	fields := store.ThingFields{Name: name}
+	if description != nil {
+		fields.Description = *description
+	}
+	if attributes != nil {
+		fields.Attributes = *attributes
+	}
+	return fields
+}
+
+// The field errors of the requests that are shared by the handlers
+var (
+	emptyName = problem.FieldError{Field: "name", Detail: "must not be empty"}
+)
+
+// Send an error response with a problem details body
+func respondError(ctx echo.Context, status int, detail string) error {
+	problem.New(ctx.Request(), status, detail).Write(ctx.Response())
+	return nil
+}
+
+// Send the error response of an error returned by the store
+func respondStoreError(ctx echo.Context, err error) error {
+	problem.FromError(ctx.Request(), err).Write(ctx.Response())
+	return nil
+}
+
+// Send a bad request response that lists the invalid fields of the request
+func respondInvalid(ctx echo.Context, errs ...problem.FieldError) error {
+	problem.Invalid(ctx.Request(), errs...).Write(ctx.Response())
+	return nil
+}
+
+// Send the error response of a request body that cannot be bound
+func respondBindError(ctx echo.Context, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return respondInvalid(ctx, problem.FieldError{Field: typeErr.Field, Detail: fmt.Sprintf("must not be a %s", typeErr.Value)})
+	}
+	return respondError(ctx, http.StatusBadRequest, "the request body is not a valid JSON object")
+}
+
+func (handler *Handler) ThingsList(ctx echo.Context, params ThingsListParams) error {
+	opts := store.ListOptions{}
+	if params.Prefix != nil {
+		opts.Prefix = *params.Prefix
+	}
+	if params.Contains != nil {
+		opts.Contains = *params.Contains
+	}
+	if params.Sort != nil {
+		opts.Sort = string(*params.Sort)
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return respondInvalid(ctx, problem.FieldError{Field: "limit", Detail: "must be a positive integer"})
+		}
+		opts.Limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		opts.Cursor = *params.Cursor
+	}
+	slog.DebugContext(ctx.Request().Context(), "ThingsList", "options", opts)
+	page, err := handler.store.ListThings(ctx.Request().Context(), opts)
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	resp := ThingList{Things: make([]Thing, 0, len(page.Things))}
+	for _, t := range page.Things {
+		resp.Things = append(resp.Things, newThing(t))
+	}
+	if page.NextCursor != "" {
+		resp.NextCursor = &page.NextCursor
+		ctx.Response().Header().Set("Link", nextLink(ctx.Request().URL, page.NextCursor))
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// Build a Link header value that points to the next page of a list
+func nextLink(u *url.URL, cursor string) string {
+	query := u.Query()
+	query.Set("cursor", cursor)
+	next := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf("<%s>; rel=\"next\"", next.String())
+}
+
+func (handler *Handler) ThingsCreate(ctx echo.Context) error {
+	var input ThingsCreateJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return respondBindError(ctx, err)
+	}
+	if input.Name == "" {
+		return respondInvalid(ctx, emptyName)
+	}
+	slog.DebugContext(ctx.Request().Context(), "ThingsCreate", "name", input.Name)
+	fields := newThingFields(input.Name, input.Description, input.Attributes)
+	t, err := handler.store.CreateThing(ctx.Request().Context(), fields)
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	ctx.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/v1/things/%s", t.ID))
+	return ctx.JSON(http.StatusCreated, newThing(t))
+}
+
+func (handler *Handler) ThingsGet(ctx echo.Context, id openapi_types.UUID) error {
+	slog.DebugContext(ctx.Request().Context(), "ThingsGet", "id", id)
+	t, err := handler.store.GetThingByID(ctx.Request().Context(), id)
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newThing(t))
+}
+
+func (handler *Handler) ThingsReplace(ctx echo.Context, id openapi_types.UUID) error {
+	var input ThingsReplaceJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return respondBindError(ctx, err)
+	}
+	if input.Name == "" {
+		return respondInvalid(ctx, emptyName)
+	}
+	slog.DebugContext(ctx.Request().Context(), "ThingsReplace", "id", id, "name", input.Name)
+	fields := newThingFields(input.Name, input.Description, input.Attributes)
+	t, err := handler.store.ReplaceThing(ctx.Request().Context(), id, fields)
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newThing(t))
+}
+
+func (handler *Handler) ThingsUpdate(ctx echo.Context, id openapi_types.UUID) error {
+	var patch ThingsUpdateJSONRequestBody
+	if err := ctx.Bind(&patch); err != nil {
+		return respondBindError(ctx, err)
+	}
+	if patch.Name != nil && *patch.Name == "" {
+		return respondInvalid(ctx, emptyName)
+	}
+	slog.DebugContext(ctx.Request().Context(), "ThingsUpdate", "id", id)
+	thingPatch := store.ThingPatch{
+		Name:        patch.Name,
+		Description: patch.Description,
+	}
+	if patch.Attributes != nil {
+		thingPatch.Attributes = *patch.Attributes
+	}
+	t, err := handler.store.PatchThing(ctx.Request().Context(), id, thingPatch)
+	if err != nil {
+		return respondStoreError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, newThing(t))
+}
+
+func (handler *Handler) ThingsDelete(ctx echo.Context, id openapi_types.UUID) error {
+	slog.DebugContext(ctx.Request().Context(), "ThingsDelete", "id", id)
+	if err := handler.store.DeleteThing(ctx.Request().Context(), id); err != nil {
+		return respondStoreError(ctx, err)
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
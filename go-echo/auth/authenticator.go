@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	HeaderAPIKey      = "X-API-Key"
+	ScopeAPIKeysAdmin = "apikeys:admin"
+	adminPathPrefix   = "/v1/admin/"
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+)
+
+// APIKeyFunc returns the identity given by an API key
+type APIKeyFunc func(ctx context.Context, key string) (*Identity, error)
+
+// Authenticator authenticates requests with an API key, a bearer token or a client certificate
+// and checks that the identity has the scope needed by the request
+type Authenticator struct {
+	Verifier        *Verifier       // Verifier verifies bearer tokens, bearer tokens are not required if it is nil
+	APIKey          APIKeyFunc      // APIKey looks up API keys
+	AdminIdentities func() []string // AdminIdentities returns the client certificate identities that may manage API keys
+}
+
+// Authenticate returns the identity of the client that sent a request
+// The identity is nil for anonymous requests, which are only allowed if bearer tokens are not required
+// An API key takes precedence over a bearer token, which takes precedence over the client certificate identity in the request context
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	return a.AuthenticateHeader(r.Context(), r.Header, RequiredScope(r))
+}
+
+// AuthenticateHeader returns the identity of the client that sent a request with a header and needs a scope
+// It authenticates the requests of protocols other than HTTP whose metadata can be given as a header
+func (a *Authenticator) AuthenticateHeader(ctx context.Context, header http.Header, scope string) (*Identity, error) {
+	var identity *Identity
+	var err error
+	switch key := header.Get(HeaderAPIKey); {
+	case key != "":
+		identity, err = a.APIKey(ctx, key)
+	case a.Verifier != nil:
+		var token string
+		if token, err = bearerToken(header); err == nil {
+			var claims *Claims
+			if claims, err = a.Verifier.Verify(token); err == nil {
+				identity = FromClaims(claims)
+			}
+		}
+	default:
+		identity = FromContext(ctx)
+		if scope != ScopeAPIKeysAdmin {
+			// the scopes of things are only enforced for API keys and bearer tokens
+			return identity, nil
+		}
+		if identity == nil {
+			return nil, ErrNoToken
+		}
+		admins := a.AdminIdentities()
+		if len(admins) == 0 || !identity.Allowed(admins) {
+			return nil, ErrInsufficientScope
+		}
+		return identity, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !identity.HasScope(scope) {
+		return nil, ErrInsufficientScope
+	}
+	return identity, nil
+}
+
+// RequiredScope returns the scope needed by a request
+func RequiredScope(r *http.Request) string {
+	if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+		return ScopeAPIKeysAdmin
+	}
+	if IsWrite(r.Method) {
+		return ScopeThingsWrite
+	}
+	return ScopeThingsRead
+}
+
+// Status returns the HTTP status code for an authentication error
+func Status(err error) int {
+	if errors.Is(err, ErrInsufficientScope) {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
@@ -0,0 +1,86 @@
+// Package auth carries the identity of the client that sent a request in the request context
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"slices"
+)
+
+// Identity is the authenticated identity of a client
+type Identity struct {
+	Subject string   // Subject is the distinguished name of the client certificate or the subject of the bearer token
+	Names   []string // Names are the common name and the DNS, URI and email subject alternative names, or the token subject
+	Scopes  []string // Scopes are the scopes granted by the bearer token
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries an identity
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+// FromContext returns the identity carried by ctx, or nil if the client has not been authenticated
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(contextKey{}).(*Identity)
+	return identity
+}
+
+// FromCertificate returns the identity given by a client certificate
+func FromCertificate(cert *x509.Certificate) *Identity {
+	identity := &Identity{
+		Subject: cert.Subject.String(),
+	}
+	if cert.Subject.CommonName != "" {
+		identity.Names = append(identity.Names, cert.Subject.CommonName)
+	}
+	identity.Names = append(identity.Names, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identity.Names = append(identity.Names, uri.String())
+	}
+	identity.Names = append(identity.Names, cert.EmailAddresses...)
+	return identity
+}
+
+// FromRequest returns the identity given by the verified client certificate of a request, or nil if there is none
+func FromRequest(r *http.Request) *Identity {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return FromCertificate(r.TLS.VerifiedChains[0][0])
+}
+
+// String returns the subject of the identity
+func (identity *Identity) String() string {
+	if identity == nil {
+		return "anonymous"
+	}
+	return identity.Subject
+}
+
+// Allowed reports whether the identity matches one of the allowed names or subjects
+// Every identity is allowed if there are no allowed names
+func (identity *Identity) Allowed(allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	if identity == nil {
+		return false
+	}
+	return slices.Contains(allowed, identity.Subject) ||
+		slices.ContainsFunc(identity.Names, func(name string) bool {
+			return slices.Contains(allowed, name)
+		})
+}
+
+// IsWrite reports whether a request method changes state
+func IsWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "microservice"
+)
+
+// testKeys are freshly generated signing keys
+type testKeys struct {
+	rsa    *rsa.PrivateKey
+	ec     *ecdsa.PrivateKey
+	secret []byte
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatal(err)
+	}
+	return &testKeys{rsa: rsaKey, ec: ecKey, secret: secret}
+}
+
+// writeJWKS writes a JSON Web Key Set with the public keys and the secret, whose key IDs are "rsa", "ec" and "hmac"
+func (keys *testKeys) writeJWKS(t *testing.T) string {
+	t.Helper()
+	b64 := base64.RawURLEncoding.EncodeToString
+	return writeFile(t, "jwks.json", map[string]any{"keys": []jsonWebKey{
+		{Kty: "RSA", Kid: "rsa", Use: "sig", N: b64(keys.rsa.N.Bytes()), E: b64(big.NewInt(int64(keys.rsa.E)).Bytes())},
+		{Kty: "EC", Kid: "ec", Crv: "P-256", X: b64(keys.ec.X.FillBytes(make([]byte, 32))), Y: b64(keys.ec.Y.FillBytes(make([]byte, 32)))},
+		{Kty: "oct", Kid: "hmac", K: b64(keys.secret)},
+	}})
+}
+
+// writeFile writes JSON content, or bytes as they are, to a file in a temporary directory
+func writeFile(t *testing.T, name string, content any) string {
+	t.Helper()
+	data, ok := content.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// publicKeyPEM returns the PEM encoding of a public key
+func publicKeyPEM(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// validClaims returns claims that the verifiers of the tests accept
+func validClaims() *Claims {
+	now := time.Now()
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "alice",
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Scope: ScopeThingsRead,
+		Scp:   []string{ScopeThingsWrite},
+	}
+}
+
+// sign signs claims with a method and key, the header has the key ID unless it is empty
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims *Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	other := newTestKeys(t)
+	verifier, err := NewVerifier(VerifierConfig{
+		JWKSFile: keys.writeJWKS(t),
+		Issuer:   testIssuer,
+		Audience: testAudience,
+		Leeway:   time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := func(change func(claims *Claims)) *Claims {
+		c := validClaims()
+		change(c)
+		return c
+	}
+	valid := validClaims()
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"HS256", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", valid), true},
+		{"RS256", sign(t, jwt.SigningMethodRS256, keys.rsa, "rsa", valid), true},
+		{"ES256", sign(t, jwt.SigningMethodES256, keys.ec, "ec", valid), true},
+		// a token without a key ID or with an unknown one is verified by every key that suits its algorithm
+		{"no kid", sign(t, jwt.SigningMethodRS256, keys.rsa, "", valid), true},
+		{"unknown kid", sign(t, jwt.SigningMethodES256, keys.ec, "rotated", valid), true},
+		// a token with a known key ID is only verified by that key
+		{"wrong kid", sign(t, jwt.SigningMethodRS256, keys.rsa, "ec", valid), false},
+		{"unknown key", sign(t, jwt.SigningMethodRS256, other.rsa, "rsa", valid), false},
+		{"unknown key without kid", sign(t, jwt.SigningMethodES256, other.ec, "", valid), false},
+		{"expired", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Minute))
+		})), false},
+		{"expired within leeway", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+		})), true},
+		{"no exp", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.ExpiresAt = nil
+		})), false},
+		{"nbf within leeway", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.NotBefore = jwt.NewNumericDate(time.Now().Add(30 * time.Second))
+		})), true},
+		{"nbf after leeway", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.NotBefore = jwt.NewNumericDate(time.Now().Add(2 * time.Minute))
+		})), false},
+		{"wrong issuer", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.Issuer = "https://other.example.com"
+		})), false},
+		{"wrong audience", sign(t, jwt.SigningMethodHS256, keys.secret, "hmac", claims(func(c *Claims) {
+			c.Audience = jwt.ClaimStrings{"other"}
+		})), false},
+		{"alg none", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", valid), false},
+		{"malformed", "not.a.token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(tt.token)
+			if !tt.valid {
+				if err == nil {
+					t.Fatal("got no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Subject != "alice" || strings.Join(got.Scopes(), " ") != ScopeThingsRead+" "+ScopeThingsWrite {
+				t.Errorf("got claims %+v", got)
+			}
+		})
+	}
+}
+
+func TestVerifyAlgorithmConfusion(t *testing.T) {
+	keys := newTestKeys(t)
+	publicPEM := publicKeyPEM(t, &keys.rsa.PublicKey)
+	cfg := VerifierConfig{
+		KeyFiles: []string{writeFile(t, "rsa.pem", publicPEM)},
+		Issuer:   testIssuer,
+		Audience: testAudience,
+	}
+	// an HS256 token whose secret is the public key must not be verified by the public key
+	token := sign(t, jwt.SigningMethodHS256, publicPEM, "", validClaims())
+	for _, secret := range []string{"", "another secret"} {
+		cfg.Secret = secret
+		verifier, err := NewVerifier(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := verifier.Verify(token); err == nil {
+			t.Errorf("secret %q: got no error", secret)
+		}
+		if _, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, keys.rsa, "", validClaims())); err != nil {
+			t.Errorf("secret %q: RS256: %v", secret, err)
+		}
+	}
+}
+
+func TestNewVerifierInvalidJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	b64 := base64.RawURLEncoding.EncodeToString
+	x := keys.ec.X.FillBytes(make([]byte, 32))
+	y := new(big.Int).Add(keys.ec.Y, big.NewInt(1)).FillBytes(make([]byte, 32))
+	tests := []struct {
+		name string
+		key  jsonWebKey
+	}{
+		{"point not on curve", jsonWebKey{Kty: "EC", Crv: "P-256", X: b64(x), Y: b64(y)}},
+		{"unsupported curve", jsonWebKey{Kty: "EC", Crv: "P-384", X: b64(x), Y: b64(y)}},
+		{"invalid base64", jsonWebKey{Kty: "RSA", N: "!", E: "AQAB"}},
+		{"unsupported key type", jsonWebKey{Kty: "OKP"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwksFile := writeFile(t, "jwks.json", map[string]any{"keys": []jsonWebKey{tt.key}})
+			if _, err := NewVerifier(VerifierConfig{JWKSFile: jwksFile}); err == nil {
+				t.Fatal("got no error")
+			}
+		})
+	}
+}
@@ -1,41 +1,439 @@
 package config
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
+	"net"
+	"net/netip"
 	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
-	DatabaseFileKey = "DatabaseFile"
-	CertKey         = "Cert"
-	PrivkeyKey      = "Privkey"
-	AddrKey         = "Addr"
-	CorsOriginKey   = "CorsOrigin"
-	ReqPerSecKey    = "ReqPerSec"
+	EnvPrefix = "MICROSERVICE_"
 )
 
+// DatabaseDrivers are the supported values of DatabaseDriver
+var DatabaseDrivers = []string{"sqlite3", "postgres", "mysql"}
+
+// clientAuthTypes maps the supported values of ClientAuth to TLS client authentication policies
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"none":    tls.NoClientCert,
+	"request": tls.VerifyClientCertIfGiven,
+	"require": tls.RequireAndVerifyClientCert,
+}
+
+// tlsVersions maps the supported values of TLSMinVersion to TLS versions
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Config holds the configuration of the application
+// Each field is read from the configuration file key given by its yaml tag and can be overridden
+// by an environment variable named EnvPrefix followed by the key in upper snake case, e.g. MICROSERVICE_REQ_PER_SEC
+// Lists are given as YAML sequences in the configuration file and as comma-separated values in the environment
+// Fields tagged with reload:"restart" are only applied when the application starts
+type Config struct {
+	DatabaseDriver          string        `yaml:"DatabaseDriver" reload:"restart"`
+	DatabaseDSN             string        `yaml:"DatabaseDSN" reload:"restart"`  // DatabaseDSN overrides DatabaseFile
+	DatabaseFile            string        `yaml:"DatabaseFile" reload:"restart"` // DatabaseFile is the data source name of configurations that predate DatabaseDSN
+	DatabaseMaxOpenConns    int           `yaml:"DatabaseMaxOpenConns" reload:"restart"`
+	DatabaseMaxIdleConns    int           `yaml:"DatabaseMaxIdleConns" reload:"restart"`
+	DatabaseConnMaxLifetime time.Duration `yaml:"DatabaseConnMaxLifetime" reload:"restart"`
+	Cert                    string        `yaml:"Cert" reload:"restart"`
+	Privkey                 string        `yaml:"Privkey" reload:"restart"`
+	TLSMinVersion           string        `yaml:"TLSMinVersion" reload:"restart"`   // TLSMinVersion is 1.2 or 1.3
+	TLSCipherSuites         []string      `yaml:"TLSCipherSuites" reload:"restart"` // TLSCipherSuites are the names of the TLS 1.2 cipher suites, empty for the Go defaults
+	ClientAuth              string        `yaml:"ClientAuth" reload:"restart"`      // ClientAuth is none, request or require
+	ClientCA                string        `yaml:"ClientCA" reload:"restart"`        // ClientCA is the CA bundle that verifies client certificates
+	JWKSFile                string        `yaml:"JWKSFile" reload:"restart"`        // JWKSFile is a JSON Web Key Set that verifies bearer tokens
+	JWTKeyFiles             []string      `yaml:"JWTKeyFiles" reload:"restart"`     // JWTKeyFiles are PEM-encoded public keys that verify bearer tokens
+	JWTSecret               string        `yaml:"JWTSecret" reload:"restart"`       // JWTSecret is an HS256 secret that verifies bearer tokens
+	JWTIssuer               string        `yaml:"JWTIssuer" reload:"restart"`
+	JWTAudience             string        `yaml:"JWTAudience" reload:"restart"`
+	JWTLeeway               time.Duration `yaml:"JWTLeeway" reload:"restart"`
+	Addr                    string        `yaml:"Addr" reload:"restart"`
+	MetricsAddr             string        `yaml:"MetricsAddr" reload:"restart"`       // MetricsAddr is the address of a separate metrics listener, metrics are served on Addr if empty
+	GRPCAddr                string        `yaml:"GRPCAddr" reload:"restart"`          // GRPCAddr is the address of a separate gRPC listener of go-echo, gRPC is served on Addr if empty
+	GRPCGateway             bool          `yaml:"GRPCGateway" reload:"restart"`       // GRPCGateway serves the REST endpoints bound in app.proto with grpc-gateway instead of the handlers
+	ShutdownDelay           time.Duration `yaml:"ShutdownDelay" reload:"restart"`     // ShutdownDelay is how long the readiness check fails before the server stops accepting requests
+	ShutdownTimeout         time.Duration `yaml:"ShutdownTimeout" reload:"restart"`   // ShutdownTimeout is how long in-flight requests have to finish when the server stops
+	OpenAPIValidation       string        `yaml:"OpenAPIValidation" reload:"restart"` // OpenAPIValidation is off, requests or strict, which also validates the responses
+	APIDocs                 bool          `yaml:"APIDocs" reload:"restart"`           // APIDocs serves the OpenAPI spec at /openapi.json and /openapi.yaml and Swagger UI at /docs/
+	CorsOrigin              []string      `yaml:"CorsOrigin"`
+	ReqPerSec               int           `yaml:"ReqPerSec"`
+	BurstSize               int           `yaml:"BurstSize"`
+	RateLimiterStore        string        `yaml:"RateLimiterStore" reload:"restart"`    // RateLimiterStore is memory, redis or sql
+	RateLimiterRedisURL     string        `yaml:"RateLimiterRedisURL" reload:"restart"` // RateLimiterRedisURL is the URL of the Redis server of the redis store
+	RouteRateLimits         []string      `yaml:"RouteRateLimits"`                      // RouteRateLimits are route=reqPerSec/burstSize limits with a token bucket of their own for each client
+	TenantRateLimits        []string      `yaml:"TenantRateLimits"`                     // TenantRateLimits are tenant=reqPerSec/burstSize limits that replace ReqPerSec and BurstSize for an identity
+	TrustedProxies          []string      `yaml:"TrustedProxies"`                       // TrustedProxies are the addresses or CIDR ranges of the proxies whose forwarding headers are trusted
+	WriteIdentities         []string      `yaml:"WriteIdentities"`                      // WriteIdentities may change things, anyone may if empty
+	AdminIdentities         []string      `yaml:"AdminIdentities"`                      // AdminIdentities may manage API keys with their client certificate
+	LogLevel                string        `yaml:"LogLevel"`                             // LogLevel is one of debug, info, warn or error
+	LogFormat               string        `yaml:"LogFormat" reload:"restart"`           // LogFormat is text or json
+	TraceExporter           string        `yaml:"TraceExporter" reload:"restart"`       // TraceExporter is none, otlp or stdout
+	TraceEndpoint           string        `yaml:"TraceEndpoint" reload:"restart"`       // TraceEndpoint is the URL of the OTLP/HTTP collector, the OTEL_EXPORTER_OTLP_ environment variables apply if empty
+	TraceFile               string        `yaml:"TraceFile" reload:"restart"`           // TraceFile is where the stdout exporter writes spans, standard output if empty
+	TraceSampleRatio        float64       `yaml:"TraceSampleRatio" reload:"restart"`    // TraceSampleRatio is the fraction of new traces that are sampled
+
+	routeLimits          []RateLimit    // routeLimits are the parsed RouteRateLimits
+	tenantLimits         []RateLimit    // tenantLimits are the parsed TenantRateLimits
+	trustedProxyPrefixes []netip.Prefix // trustedProxyPrefixes are the parsed TrustedProxies
+}
+
 var (
-	Data = map[string]string{}
+	cfg      atomic.Pointer[Config]
+	fileName string
 )
 
+func init() {
+	cfg.Store(Default())
+}
+
+// Default returns the configuration used for keys that are not set
+func Default() *Config {
+	return &Config{
+		DatabaseDriver:       "sqlite3",
+		DatabaseFile:         "file:store.db?_fk=1",
+		DatabaseMaxIdleConns: 2,
+		TLSMinVersion:        "1.2",
+		ClientAuth:           "none",
+		JWTLeeway:            30 * time.Second,
+		Addr:                 "0.0.0.0:4443",
+		ShutdownTimeout:      30 * time.Second,
+		OpenAPIValidation:    "requests",
+		CorsOrigin:           []string{"https://localhost"},
+		ReqPerSec:            10,
+		BurstSize:            20,
+		RateLimiterStore:     "memory",
+		LogLevel:             "info",
+		LogFormat:            "text",
+		TraceExporter:        "none",
+		TraceSampleRatio:     1,
+	}
+}
+
+// Get returns the current configuration
+// The configuration must not be modified, it is replaced as a whole when it is reloaded
+func Get() *Config {
+	return cfg.Load()
+}
+
+// Open loads the configuration from the defaults, the configuration file and the environment, in that order
+// The configuration file is optional if filename is empty
+// All invalid values are reported together
 func Open(filename string) error {
-	content, err := os.ReadFile(filename)
+	c, err := Load(filename)
 	if err != nil {
-		return fmt.Errorf("failed to read configuration file: %s: %w", filename, err)
+		return err
+	}
+	fileName = filename
+	cfg.Store(c)
+	return nil
+}
+
+// Load reads and validates a configuration without making it current
+func Load(filename string) (*Config, error) {
+	c := Default()
+	var errs []error
+	if filename != "" {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration file: %s: %w", filename, err)
+		}
+		var nodes map[string]yaml.Node
+		if err = yaml.Unmarshal(content, &nodes); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration file: %s: %w", filename, err)
+		}
+		errs = append(errs, c.setFromFile(nodes)...)
 	}
-	if err = yaml.Unmarshal([]byte(content), &Data); err != nil {
-		return fmt.Errorf("failed to parse configuration file: %s: %w", filename, err)
+	errs = append(errs, c.setFromEnv()...)
+	errs = append(errs, c.Validate())
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	c.parseLimits()
+	return c, nil
+}
+
+// EnvName returns the name of the environment variable that overrides a configuration key
+func EnvName(key string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	runes := []rune(key)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// setFromFile sets the fields given in the configuration file
+func (c *Config) setFromFile(nodes map[string]yaml.Node) []error {
+	var errs []error
+	fields := c.fields()
+	for _, key := range slices.Sorted(maps.Keys(nodes)) {
+		node := nodes[key]
+		field, ok := fields[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown key: %s", key))
+			continue
+		}
+		var err error
+		if node.Kind == yaml.SequenceNode && field.Kind() == reflect.Slice {
+			var list []string
+			if err = node.Decode(&list); err == nil {
+				field.Set(reflect.ValueOf(list))
+			}
+		} else if node.Kind == yaml.ScalarNode {
+			err = setField(field, node.Value)
+		} else {
+			err = errors.New("expected a value")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return errs
+}
+
+// setFromEnv sets the fields that are overridden in the environment
+func (c *Config) setFromEnv() []error {
+	var errs []error
+	fields := c.fields()
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		field := fields[key]
+		name := EnvName(key)
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setField(field, val); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// fields maps configuration keys to the fields of c
+// The unexported fields hold values that are parsed from the others so they are not keys
+func (c *Config) fields() map[string]reflect.Value {
+	fields := map[string]reflect.Value{}
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Type().Field(i); field.IsExported() {
+			fields[field.Tag.Get("yaml")] = v.Field(i)
+		}
+	}
+	return fields
+}
+
+// setField parses a string value into a field
+func setField(field reflect.Value, val string) error {
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(val)
+	case bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("invalid boolean: %q", val)
+		}
+		field.SetBool(b)
+	case int:
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("invalid integer: %q", val)
+		}
+		field.SetInt(int64(n))
+	case float64:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number: %q", val)
+		}
+		field.SetFloat(f)
+	case time.Duration:
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %q", val)
+		}
+		field.SetInt(int64(d))
+	case []string:
+		var list []string
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		field.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported type: %s", field.Type())
 	}
 	return nil
 }
 
-func Get(key string) string {
-	val, ok := Data[key]
-	if !ok {
-		log.Printf("configuration data missing for key: '%s'", key)
+// Validate checks the values of the configuration and returns all of the problems found
+func (c *Config) Validate() error {
+	var errs []error
+	supported := false
+	for _, driver := range DatabaseDrivers {
+		supported = supported || c.DatabaseDriver == driver
+	}
+	if !supported {
+		errs = append(errs, fmt.Errorf("DatabaseDriver: must be one of %s: %q", strings.Join(DatabaseDrivers, ", "), c.DatabaseDriver))
+	}
+	if c.DatabaseDSN == "" && c.DatabaseFile == "" {
+		errs = append(errs, errors.New("DatabaseDSN: must be set if DatabaseFile is not set"))
+	}
+	if c.DatabaseMaxOpenConns < 0 {
+		errs = append(errs, fmt.Errorf("DatabaseMaxOpenConns: must not be negative: %d", c.DatabaseMaxOpenConns))
+	}
+	if c.DatabaseMaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("DatabaseMaxIdleConns: must not be negative: %d", c.DatabaseMaxIdleConns))
+	}
+	if c.DatabaseConnMaxLifetime < 0 {
+		errs = append(errs, fmt.Errorf("DatabaseConnMaxLifetime: must not be negative: %s", c.DatabaseConnMaxLifetime))
+	}
+	if _, _, err := net.SplitHostPort(c.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("Addr: %w", err))
+	}
+	if c.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			errs = append(errs, fmt.Errorf("MetricsAddr: %w", err))
+		}
+	}
+	if c.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(c.GRPCAddr); err != nil {
+			errs = append(errs, fmt.Errorf("GRPCAddr: %w", err))
+		}
+	}
+	if c.ShutdownDelay < 0 {
+		errs = append(errs, fmt.Errorf("ShutdownDelay: must not be negative: %s", c.ShutdownDelay))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("ShutdownTimeout: must be positive: %s", c.ShutdownTimeout))
+	}
+	if (c.Cert == "") != (c.Privkey == "") {
+		errs = append(errs, errors.New("Cert and Privkey: must be set together"))
+	}
+	if _, ok := tlsVersions[c.TLSMinVersion]; !ok {
+		errs = append(errs, fmt.Errorf("TLSMinVersion: must be 1.2 or 1.3: %q", c.TLSMinVersion))
+	}
+	for _, name := range c.TLSCipherSuites {
+		if _, ok := tlsCipherSuites()[name]; !ok {
+			errs = append(errs, fmt.Errorf("TLSCipherSuites: unknown or insecure cipher suite: %q", name))
+		}
+	}
+	if clientAuth, ok := clientAuthTypes[c.ClientAuth]; !ok {
+		errs = append(errs, fmt.Errorf("ClientAuth: must be none, request or require: %q", c.ClientAuth))
+	} else if clientAuth != tls.NoClientCert && c.ClientCA == "" {
+		errs = append(errs, errors.New("ClientCA: must be set if ClientAuth is not none"))
+	}
+	if c.JWTEnabled() && (c.JWTIssuer == "" || c.JWTAudience == "") {
+		errs = append(errs, errors.New("JWTIssuer and JWTAudience: must be set if bearer tokens are verified"))
+	}
+	if c.JWTLeeway < 0 {
+		errs = append(errs, fmt.Errorf("JWTLeeway: must not be negative: %s", c.JWTLeeway))
+	}
+	if len(c.CorsOrigin) == 0 {
+		errs = append(errs, errors.New("CorsOrigin: must not be empty"))
+	}
+	switch c.OpenAPIValidation {
+	case "off", "requests", "strict":
+	default:
+		errs = append(errs, fmt.Errorf("OpenAPIValidation: must be off, requests or strict: %q", c.OpenAPIValidation))
+	}
+	if c.ReqPerSec <= 0 {
+		errs = append(errs, fmt.Errorf("ReqPerSec: must be positive: %d", c.ReqPerSec))
+	}
+	if c.BurstSize <= 0 {
+		errs = append(errs, fmt.Errorf("BurstSize: must be positive: %d", c.BurstSize))
+	}
+	errs = append(errs, c.validateRateLimits()...)
+	switch c.RateLimiterStore {
+	case "memory", "sql":
+	case "redis":
+		if c.RateLimiterRedisURL == "" {
+			errs = append(errs, errors.New("RateLimiterRedisURL: must be set if RateLimiterStore is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("RateLimiterStore: must be memory, redis or sql: %q", c.RateLimiterStore))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("LogLevel: %w", err))
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		errs = append(errs, fmt.Errorf("LogFormat: must be text or json: %q", c.LogFormat))
+	}
+	if c.TraceExporter != "none" && c.TraceExporter != "otlp" && c.TraceExporter != "stdout" {
+		errs = append(errs, fmt.Errorf("TraceExporter: must be none, otlp or stdout: %q", c.TraceExporter))
+	}
+	if c.TraceSampleRatio < 0 || c.TraceSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("TraceSampleRatio: must be between 0 and 1: %g", c.TraceSampleRatio))
+	}
+	return errors.Join(errs...)
+}
+
+// DSN returns the data source name of the database
+func (c *Config) DSN() string {
+	if c.DatabaseDSN != "" {
+		return c.DatabaseDSN
+	}
+	return c.DatabaseFile
+}
+
+// Level returns the log level
+func (c *Config) Level() slog.Level {
+	var level slog.Level
+	level.UnmarshalText([]byte(c.LogLevel)) // the log level has been validated
+	return level
+}
+
+// TLSVersion returns the minimum TLS version
+func (c *Config) TLSVersion() uint16 {
+	return tlsVersions[c.TLSMinVersion]
+}
+
+// JWTEnabled reports whether requests must carry a valid bearer token
+func (c *Config) JWTEnabled() bool {
+	return c.JWKSFile != "" || len(c.JWTKeyFiles) > 0 || c.JWTSecret != ""
+}
+
+// ClientAuthType returns the TLS client authentication policy
+func (c *Config) ClientAuthType() tls.ClientAuthType {
+	return clientAuthTypes[c.ClientAuth]
+}
+
+// TLSCipherSuiteIDs returns the IDs of the TLS cipher suites, nil for the Go defaults
+func (c *Config) TLSCipherSuiteIDs() []uint16 {
+	var ids []uint16
+	for _, name := range c.TLSCipherSuites {
+		ids = append(ids, tlsCipherSuites()[name])
+	}
+	return ids
+}
+
+// tlsCipherSuites maps the names of the cipher suites without known security issues to their IDs
+func tlsCipherSuites() map[string]uint16 {
+	suites := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		suites[suite.Name] = suite.ID
 	}
-	return val
+	return suites
 }
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes a configuration file into a temporary directory and returns its name
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"Addr", "MICROSERVICE_ADDR"},
+		{"ReqPerSec", "MICROSERVICE_REQ_PER_SEC"},
+		{"DatabaseDSN", "MICROSERVICE_DATABASE_DSN"},
+		{"TLSMinVersion", "MICROSERVICE_TLS_MIN_VERSION"},
+		{"JWKSFile", "MICROSERVICE_JWKS_FILE"},
+		{"APIDocs", "MICROSERVICE_API_DOCS"},
+	}
+	for _, tt := range tests {
+		if got := EnvName(tt.key); got != tt.want {
+			t.Errorf("EnvName(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	c, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c, Default()) {
+		t.Errorf("got %+v, want the defaults %+v", c, Default())
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	c, err := Load(writeFile(t, `
+DatabaseMaxOpenConns: "10"
+DatabaseConnMaxLifetime: "30m"
+APIDocs: "true"
+TraceSampleRatio: "0.5"
+CorsOrigin:
+  - "https://a.example.org"
+  - "https://b.example.org"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.DatabaseMaxOpenConns != 10 || c.DatabaseConnMaxLifetime != 30*time.Minute || !c.APIDocs || c.TraceSampleRatio != 0.5 ||
+		!reflect.DeepEqual(c.CorsOrigin, []string{"https://a.example.org", "https://b.example.org"}) {
+		t.Errorf("got %+v", c)
+	}
+	// the keys that are not in the file keep their defaults
+	if c.Addr != Default().Addr || c.ReqPerSec != Default().ReqPerSec {
+		t.Errorf("got Addr %q and ReqPerSec %d, want the defaults", c.Addr, c.ReqPerSec)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	filename := writeFile(t, `
+DatabaseConnMaxLifetime: "10s"
+APIDocs: "true"
+ReqPerSec: "5"
+CorsOrigin:
+  - "https://a.example.org"
+`)
+	t.Setenv("MICROSERVICE_DATABASE_CONN_MAX_LIFETIME", "45s")
+	t.Setenv("MICROSERVICE_API_DOCS", "false")
+	t.Setenv("MICROSERVICE_REQ_PER_SEC", "100")
+	t.Setenv("MICROSERVICE_CORS_ORIGIN", "https://b.example.org, https://c.example.org,")
+	t.Setenv("MICROSERVICE_TRUSTED_PROXIES", "10.0.0.0/8,192.0.2.1")
+	c, err := Load(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.DatabaseConnMaxLifetime != 45*time.Second || c.APIDocs || c.ReqPerSec != 100 {
+		t.Errorf("got DatabaseConnMaxLifetime %s, APIDocs %t and ReqPerSec %d", c.DatabaseConnMaxLifetime, c.APIDocs, c.ReqPerSec)
+	}
+	if !reflect.DeepEqual(c.CorsOrigin, []string{"https://b.example.org", "https://c.example.org"}) {
+		t.Errorf("got CorsOrigin %q", c.CorsOrigin)
+	}
+	if !reflect.DeepEqual(c.TrustedProxies, []string{"10.0.0.0/8", "192.0.2.1"}) {
+		t.Errorf("got TrustedProxies %q", c.TrustedProxies)
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("got no error for a missing configuration file")
+	}
+}
+
+func TestLoadUnknownKey(t *testing.T) {
+	_, err := Load(writeFile(t, `
+Addr: "0.0.0.0:8443"
+ReqPerSecond: "10"
+`))
+	if err == nil || !strings.Contains(err.Error(), "unknown key: ReqPerSecond") {
+		t.Errorf("got error %v, want the unknown key", err)
+	}
+}
+
+func TestLoadInvalidValues(t *testing.T) {
+	filename := writeFile(t, `
+DatabaseDriver: "oracle"
+CorsOrigin:
+  nested: "map"
+`)
+	t.Setenv("MICROSERVICE_REQ_PER_SEC", "ten")
+	t.Setenv("MICROSERVICE_DATABASE_CONN_MAX_LIFETIME", "5 seconds")
+	t.Setenv("MICROSERVICE_API_DOCS", "maybe")
+	t.Setenv("MICROSERVICE_BURST_SIZE", "-1")
+	_, err := Load(filename)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	// every problem is reported, not only the first one
+	for _, want := range []string{
+		`DatabaseDriver: must be one of sqlite3, postgres, mysql: "oracle"`,
+		"CorsOrigin: expected a value",
+		`MICROSERVICE_REQ_PER_SEC: invalid integer: "ten"`,
+		`MICROSERVICE_DATABASE_CONN_MAX_LIFETIME: invalid duration: "5 seconds"`,
+		`MICROSERVICE_API_DOCS: invalid boolean: "maybe"`,
+		"BurstSize: must be positive: -1",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got error %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	if _, err := Load("../../config.yaml"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestConfigFileKeys fails if a key in config.yaml, including the keys of the examples that are commented out,
+// is not known, since the server would not start with it
+func TestConfigFileKeys(t *testing.T) {
+	content, err := os.ReadFile("../../config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := Default().fields()
+	for _, match := range regexp.MustCompile(`(?m)^(?:# )?([A-Z]\w*):(?: |$)`).FindAllStringSubmatch(string(content), -1) {
+		if _, ok := fields[match[1]]; !ok {
+			t.Errorf("unknown key: %s", match[1])
+		}
+	}
+}
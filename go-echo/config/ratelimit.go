@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// RateLimit is a rate limit that overrides ReqPerSec and BurstSize for a route or a tenant
+// It is written as name=reqPerSec/burstSize, e.g. "POST /v1/set=1/5" or "ci=100/200"
+type RateLimit struct {
+	Name      string // Name is a route, with or without a method, or a tenant
+	ReqPerSec int
+	BurstSize int
+}
+
+// parseRateLimit parses a rate limit written as name=reqPerSec/burstSize
+func parseRateLimit(entry string) (RateLimit, error) {
+	// the name is split at the last = since names such as distinguished names may contain =
+	i := strings.LastIndex(entry, "=")
+	if i <= 0 {
+		return RateLimit{}, fmt.Errorf("must be name=reqPerSec/burstSize: %q", entry)
+	}
+	rate, burst, ok := strings.Cut(entry[i+1:], "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("must be name=reqPerSec/burstSize: %q", entry)
+	}
+	limit := RateLimit{
+		Name: strings.TrimSpace(entry[:i]),
+	}
+	var err error
+	if limit.ReqPerSec, err = strconv.Atoi(strings.TrimSpace(rate)); err != nil || limit.ReqPerSec <= 0 {
+		return RateLimit{}, fmt.Errorf("rate must be a positive integer: %q", entry)
+	}
+	if limit.BurstSize, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil || limit.BurstSize <= 0 {
+		return RateLimit{}, fmt.Errorf("burst size must be a positive integer: %q", entry)
+	}
+	return limit, nil
+}
+
+// parseRateLimits parses a list of rate limits, invalid entries are skipped since they are reported by Validate
+func parseRateLimits(entries []string) []RateLimit {
+	var limits []RateLimit
+	for _, entry := range entries {
+		if limit, err := parseRateLimit(entry); err == nil {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
+
+// parseLimits parses the rate limits and trusted proxies when the configuration is loaded, rather than for each request
+func (c *Config) parseLimits() {
+	c.routeLimits = parseRateLimits(c.RouteRateLimits)
+	c.tenantLimits = parseRateLimits(c.TenantRateLimits)
+	c.trustedProxyPrefixes = nil
+	for _, proxy := range c.TrustedProxies {
+		if prefix, err := parsePrefix(proxy); err == nil {
+			c.trustedProxyPrefixes = append(c.trustedProxyPrefixes, prefix)
+		}
+	}
+}
+
+// RouteLimits returns the rate limits of the routes, in the order in which they are configured
+func (c *Config) RouteLimits() []RateLimit {
+	return c.routeLimits
+}
+
+// TenantLimits returns the rate limits of the tenants, in the order in which they are configured
+func (c *Config) TenantLimits() []RateLimit {
+	return c.tenantLimits
+}
+
+// TrustedProxyPrefixes returns the address ranges of the trusted proxies
+// A single address is a range of one address
+func (c *Config) TrustedProxyPrefixes() []netip.Prefix {
+	return c.trustedProxyPrefixes
+}
+
+// parsePrefix parses a CIDR address range or a single address
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// validateRateLimits reports the invalid rate limits and trusted proxies
+func (c *Config) validateRateLimits() []error {
+	var errs []error
+	for _, entry := range c.RouteRateLimits {
+		limit, err := parseRateLimit(entry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("RouteRateLimits: %w", err))
+		} else if route := limit.Name[strings.LastIndex(limit.Name, " ")+1:]; !strings.HasPrefix(route, "/") {
+			errs = append(errs, fmt.Errorf("RouteRateLimits: route must start with /: %q", entry))
+		}
+	}
+	for _, entry := range c.TenantRateLimits {
+		if _, err := parseRateLimit(entry); err != nil {
+			errs = append(errs, fmt.Errorf("TenantRateLimits: %w", err))
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, err := parsePrefix(proxy); err != nil {
+			errs = append(errs, fmt.Errorf("TrustedProxies: %w", err))
+		}
+	}
+	return errs
+}
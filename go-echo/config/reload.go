@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	watchDelay = 100 * time.Millisecond // watchDelay lets a burst of file events settle before the file is reloaded
+)
+
+var (
+	hooksMu  sync.Mutex
+	hooks    []func(*Config)
+	reloadMu sync.Mutex
+)
+
+// OnReload registers a function that is called with the new configuration after each successful reload
+func OnReload(hook func(*Config)) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// Reload loads the configuration again from the file given to Open and the environment and makes it current
+// If the new configuration is invalid then the current configuration is kept
+// Changes to keys that are only applied when the application starts are logged and otherwise ignored
+func Reload() error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	c, err := Load(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+	old := Get()
+	for _, key := range keepRestartFields(old, c) {
+		slog.Warn("configuration key has changed, restart the application to apply it", "key", key)
+	}
+	cfg.Store(c)
+	slog.Info("configuration reloaded")
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	for _, hook := range hooks {
+		hook(c)
+	}
+	return nil
+}
+
+// keepRestartFields copies the fields that are only applied at start from old to c
+// It returns the keys of the fields that have changed
+func keepRestartFields(old, c *Config) []string {
+	var changed []string
+	oldVal := reflect.ValueOf(old).Elem()
+	val := reflect.ValueOf(c).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if field.Tag.Get("reload") != "restart" {
+			continue
+		}
+		if !reflect.DeepEqual(oldVal.Field(i).Interface(), val.Field(i).Interface()) {
+			changed = append(changed, field.Tag.Get("yaml"))
+			val.Field(i).Set(oldVal.Field(i))
+		}
+	}
+	return changed
+}
+
+// Watch reloads the configuration whenever the file given to Open changes
+// The directory is watched rather than the file so that files replaced by editors and
+// by Kubernetes ConfigMap updates are followed
+func Watch() error {
+	if fileName == "" {
+		return fmt.Errorf("failed to watch configuration file: no configuration file")
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch configuration file: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(fileName)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch configuration file: %w", err)
+	}
+	go watchLoop(watcher)
+	slog.Info("watching configuration file", "file", fileName)
+	return nil
+}
+
+// watchLoop reloads the configuration after events for the configuration file
+func watchLoop(watcher *fsnotify.Watcher) {
+	ops := []fsnotify.Op{fsnotify.Write, fsnotify.Create, fsnotify.Rename}
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(fileName) &&
+				filepath.Base(event.Name) != "..data" { // Kubernetes swaps the ..data symlink
+				continue
+			}
+			if !slices.ContainsFunc(ops, event.Has) {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDelay, func() {
+				if err := Reload(); err != nil {
+					slog.Error("keeping the current configuration", "error", err)
+				}
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("configuration file watcher error", "error", err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// openFile makes the configuration in a file current until the end of a test
+func openFile(t *testing.T, content string) string {
+	t.Helper()
+	filename := writeFile(t, content)
+	if err := Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := Open(""); err != nil {
+			t.Error(err)
+		}
+	})
+	return filename
+}
+
+// subscribe returns a channel that receives the configurations that are reloaded from now on
+// The hooks cannot be removed, so the channel is never closed and it drops the configurations that are not received
+func subscribe() <-chan *Config {
+	ch := make(chan *Config, 1)
+	OnReload(func(c *Config) {
+		select {
+		case ch <- c:
+		default:
+		}
+	})
+	return ch
+}
+
+// received waits for a reloaded configuration
+func received(t *testing.T, ch <-chan *Config) *Config {
+	t.Helper()
+	select {
+	case c := <-ch:
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("the configuration was not reloaded")
+		return nil
+	}
+}
+
+func TestKeepRestartFields(t *testing.T) {
+	old := Default()
+	c := Default()
+	c.Addr = "0.0.0.0:8443"
+	c.DatabaseDriver = "postgres"
+	c.ReqPerSec = 100
+	c.CorsOrigin = []string{"https://example.org"}
+	changed := keepRestartFields(old, c)
+	if !slices.Equal(changed, []string{"DatabaseDriver", "Addr"}) {
+		t.Errorf("got the changed keys %q, want DatabaseDriver and Addr", changed)
+	}
+	if c.Addr != old.Addr || c.DatabaseDriver != old.DatabaseDriver {
+		t.Errorf("got Addr %q and DatabaseDriver %q, want the values that the application started with", c.Addr, c.DatabaseDriver)
+	}
+	if c.ReqPerSec != 100 || !slices.Equal(c.CorsOrigin, []string{"https://example.org"}) {
+		t.Errorf("got ReqPerSec %d and CorsOrigin %q, want the reloaded values", c.ReqPerSec, c.CorsOrigin)
+	}
+}
+
+func TestReload(t *testing.T) {
+	filename := openFile(t, `
+Addr: "0.0.0.0:4443"
+ReqPerSec: "10"
+`)
+	ch := subscribe()
+	if err := os.WriteFile(filename, []byte(`
+Addr: "0.0.0.0:8443"
+ReqPerSec: "20"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if c := Get(); c.ReqPerSec != 20 || c.Addr != "0.0.0.0:4443" {
+		t.Errorf("got ReqPerSec %d and Addr %q, want the new rate and the address that the application started with", c.ReqPerSec, c.Addr)
+	}
+	if c := received(t, ch); c != Get() {
+		t.Errorf("the subscriber got %+v, want the current configuration", c)
+	}
+}
+
+func TestReloadInvalid(t *testing.T) {
+	filename := openFile(t, `
+ReqPerSec: "10"
+`)
+	current := Get()
+	ch := subscribe()
+	if err := os.WriteFile(filename, []byte(`
+ReqPerSec: "20"
+LogLevel: "verbose"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err == nil {
+		t.Fatal("got no error")
+	}
+	if Get() != current {
+		t.Errorf("got %+v, want the previous configuration", Get())
+	}
+	select {
+	case c := <-ch:
+		t.Errorf("the subscriber got %+v", c)
+	default:
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(filename string, content []byte) error
+	}{
+		{"written", func(filename string, content []byte) error {
+			return os.WriteFile(filename, content, 0o600)
+		}},
+		// editors and Kubernetes replace the file rather than write it
+		{"replaced", func(filename string, content []byte) error {
+			tmp := filepath.Join(filepath.Dir(filename), "config.yaml.tmp")
+			if err := os.WriteFile(tmp, content, 0o600); err != nil {
+				return err
+			}
+			return os.Rename(tmp, filename)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := openFile(t, `
+ReqPerSec: "10"
+`)
+			ch := subscribe()
+			if err := Watch(); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.write(filename, []byte(`
+ReqPerSec: "30"
+`)); err != nil {
+				t.Fatal(err)
+			}
+			if c := received(t, ch); c.ReqPerSec != 30 {
+				t.Errorf("got ReqPerSec %d, want 30", c.ReqPerSec)
+			}
+		})
+	}
+}
+
+func TestWatchWithoutFile(t *testing.T) {
+	if err := Open(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := Watch(); err == nil {
+		t.Error("got no error")
+	}
+}
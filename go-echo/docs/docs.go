@@ -0,0 +1,84 @@
+// Package docs serves the OpenAPI spec and Swagger UI
+//
+// The Swagger UI assets are embedded in the binary so that the docs work without access to a CDN
+package docs
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ui holds the files that replace those of Swagger UI's distribution so that it shows the spec of the service
+//
+//go:embed ui
+var ui embed.FS
+
+// Docs serves a spec as JSON and YAML, and Swagger UI for it
+type Docs struct {
+	json  []byte
+	yaml  []byte
+	files http.Handler
+}
+
+// New creates the docs of a spec
+func New(spec *openapi3.T) (*Docs, error) {
+	jsonSpec, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec as JSON: %w", err)
+	}
+	yamlSpec, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec as YAML: %w", err)
+	}
+	uiFiles, err := fs.Sub(ui, "ui")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Swagger UI files: %w", err)
+	}
+	return &Docs{
+		json:  jsonSpec,
+		yaml:  yamlSpec,
+		files: http.FileServerFS(overlay{FS: swaggerFiles.FS, ui: uiFiles}),
+	}, nil
+}
+
+// JSON serves the spec as JSON
+func (d *Docs) JSON(w http.ResponseWriter, r *http.Request) {
+	serveSpec(w, "application/json", d.json)
+}
+
+// YAML serves the spec as YAML
+func (d *Docs) YAML(w http.ResponseWriter, r *http.Request) {
+	serveSpec(w, "application/yaml", d.yaml)
+}
+
+// UI serves Swagger UI, the request path is relative to where it is mounted
+func (d *Docs) UI(w http.ResponseWriter, r *http.Request) {
+	d.files.ServeHTTP(w, r)
+}
+
+// serveSpec sends an encoded spec
+func serveSpec(w http.ResponseWriter, contentType string, spec []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(spec)
+}
+
+// overlay is a file system whose ui files replace those of another
+type overlay struct {
+	fs.FS
+	ui fs.FS
+}
+
+func (o overlay) Open(name string) (fs.File, error) {
+	if f, err := o.ui.Open(name); err == nil {
+		return f, nil
+	}
+	return o.FS.Open(name)
+}
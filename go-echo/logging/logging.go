@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/keith-cullen/microservice/config"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// level is shared by every handler so that a reloaded log level applies immediately
+var level slog.LevelVar
+
+// Setup makes a structured logger the default logger, including for the log package
+// The log level follows configuration reloads, the log format is only read at startup
+func Setup(w io.Writer) {
+	cfg := config.Get()
+	level.Set(cfg.Level())
+	opts := &slog.HandlerOptions{Level: &level}
+	var handler slog.Handler
+	if cfg.LogFormat == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	slog.SetDefault(slog.New(contextHandler{handler}))
+	config.OnReload(func(cfg *config.Config) {
+		level.Set(cfg.Level())
+	})
+}
+
+// requestKey is the context key of the requestInfo
+type requestKey struct{}
+
+// requestInfo identifies the request that a record was emitted for
+type requestInfo struct {
+	id       string
+	remoteIP string
+}
+
+// NewContext returns a context whose log records are attributed to a request
+func NewContext(ctx context.Context, requestID, remoteIP string) context.Context {
+	return context.WithValue(ctx, requestKey{}, requestInfo{id: requestID, remoteIP: remoteIP})
+}
+
+// RequestID returns the ID of the request that a context belongs to, or an empty string
+func RequestID(ctx context.Context) string {
+	info, _ := ctx.Value(requestKey{}).(requestInfo)
+	return info.id
+}
+
+// contextHandler adds the request ID, remote IP and trace context in the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if info, ok := ctx.Value(requestKey{}).(requestInfo); ok {
+		r.AddAttrs(slog.String("request_id", info.id), slog.String("remote_ip", info.remoteIP))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/ratelimit"
+)
+
+const (
+	HeaderRequestID    = "X-Request-ID"
+	maxRequestIDLength = 128
+)
+
+// responseWriter records the status code and size of a response
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Unwrap gives http.ResponseController access to the underlying response writer
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher, which gRPC needs to send the messages of a call as they are written
+func (w *responseWriter) Flush() {
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Middleware attributes the log records of a request to its request ID and remote IP
+// and emits one access log record per request
+// An incoming X-Request-ID header is used as the request ID if it is valid, otherwise one is generated
+// The remote IP is the client IP that requests are rate limited by, which is only taken from the forwarding headers of trusted proxies
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestID := r.Header.Get(HeaderRequestID)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		remoteIP := r.RemoteAddr
+		if ip, err := ratelimit.ClientIP(r, config.Get().TrustedProxyPrefixes()); err == nil {
+			remoteIP = ip.String()
+		}
+		ctx := NewContext(r.Context(), requestID, remoteIP)
+		w.Header().Set(HeaderRequestID, requestID)
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+		slog.LogAttrs(ctx, slog.LevelInfo, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rw.status),
+			slog.Int("bytes", rw.bytes),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("user_agent", r.UserAgent()),
+		)
+	})
+}
+
+// validRequestID reports whether a client supplied request ID can be logged safely
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,20 +1,27 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/logging"
 	"github.com/keith-cullen/microservice/server"
 	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/tracing"
 )
 
 type cmdLineOpts struct {
 	insecure       bool
 	configFileName string
+	watchConfig    bool
 }
 
 var (
@@ -22,44 +29,129 @@ var (
 	flags *flag.FlagSet
 )
 
+// Exit codes, the flag package exits with 2 if the command line is invalid
+const (
+	exitOK       = 0 // exitOK is returned when the server stopped cleanly or a command succeeded
+	exitError    = 1 // exitError is returned when the configuration, store or server could not be set up or a command failed
+	exitServe    = 3 // exitServe is returned when the server failed while serving, e.g. because its address is in use
+	exitShutdown = 4 // exitShutdown is returned when the server did not stop cleanly, e.g. because requests outlived ShutdownTimeout
+)
+
 func main() {
+	os.Exit(run())
+}
+
+// run runs the application and returns its exit code
+// It returns rather than exits so that the deferred cleanup is done
+func run() int {
 	flags = flag.NewFlagSet("", flag.ExitOnError)
 	flags.BoolVar(&opts.insecure, "i", false, "insecure (HTTP) mode")
-	flags.StringVar(&opts.configFileName, "c", "", "config file name")
+	flags.StringVar(&opts.configFileName, "c", "", "config file name, optional if the configuration is given in the environment")
+	flags.BoolVar(&opts.watchConfig, "w", false, "reload the config file when it changes")
 	flags.Usage = func() {
-		fmt.Fprintf(flags.Output(), "usage: %s [OPTIONS]...\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "usage: %s [OPTIONS]... [migrate up|down|status]\n", os.Args[0])
 		flags.PrintDefaults()
 	}
 	flags.Parse(os.Args[1:]) // ExitOnError so no need to check the return value
+	err := config.Open(opts.configFileName)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return exitError
+	}
+	logging.Setup(os.Stderr)
 	if opts.insecure {
-		log.Print("insecure")
+		slog.Warn("insecure")
 	}
-	err := config.Open(opts.configFileName)
+	if flags.NArg() > 0 {
+		if err := runCommand(flags.Args()); err != nil {
+			slog.Error("command failed", "error", err)
+			return exitError
+		}
+		return exitOK
+	}
+	go reloadOnHangup()
+	shutdownTracing, err := tracing.Setup(context.Background())
 	if err != nil {
-		log.Fatalf("error: %v", err)
+		slog.Error("failed to start", "error", err)
+		return exitError
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to stop tracing", "error", err)
+		}
+	}()
+	if opts.watchConfig {
+		if err := config.Watch(); err != nil {
+			slog.Error("failed to start", "error", err)
+			return exitError
+		}
 	}
 	store, err := store.Open()
 	if err != nil {
-		log.Fatalf("error: %v", err)
+		slog.Error("failed to start", "error", err)
+		return exitError
 	}
+	// the store is closed after the server has stopped so that in-flight requests can finish with it
 	defer store.Close()
 	server, err := server.New(store)
 	if err != nil {
-		log.Fatalf("error: %v", err)
+		slog.Error("failed to start", "error", err)
+		return exitError
 	}
-	done := make(chan struct{})
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	errCh := make(chan error, 1)
 	go func() {
-		sigCh := make(chan os.Signal, 1)
-		signal.Notify(sigCh, os.Interrupt)
-		<-sigCh
-		if err := server.Stop(); err != nil {
-			log.Fatalf("error: %v", err)
-		}
-		close(done)
+		errCh <- server.Start(opts.insecure)
 	}()
-	err = server.Start(opts.insecure)
-	if err != nil {
-		log.Fatal("error: %v", err)
+	select {
+	case err := <-errCh:
+		// Start only returns before Stop is called if the server fails
+		slog.Error("http server failed", "error", err)
+		return exitServe
+	case sig := <-sigCh:
+		slog.Info("shutting down", "signal", sig.String())
+	}
+	// a second signal terminates the process without waiting for the shutdown to finish
+	signal.Stop(sigCh)
+	code := exitOK
+	if err := server.Stop(); err != nil {
+		slog.Error("http server did not stop cleanly", "error", err)
+		code = exitShutdown
+	}
+	if err := <-errCh; err != nil {
+		slog.Error("http server failed", "error", err)
+		code = exitServe
+	}
+	return code
+}
+
+// reloadOnHangup reloads the configuration each time the process receives SIGHUP
+func reloadOnHangup() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	for range sigCh {
+		slog.Info("SIGHUP received")
+		if err := config.Reload(); err != nil {
+			slog.Error("keeping the current configuration", "error", err)
+		}
+	}
+}
+
+// runCommand runs a command given on the command line instead of the server
+func runCommand(args []string) error {
+	if len(args) != 2 || args[0] != "migrate" {
+		return fmt.Errorf("unknown command: %s", strings.Join(args, " "))
+	}
+	ctx := context.Background()
+	switch args[1] {
+	case "up":
+		return store.MigrateUp(ctx, os.Stdout)
+	case "down":
+		return store.MigrateDown(ctx, os.Stdout)
+	case "status":
+		return store.MigrateStatus(ctx, os.Stdout)
+	default:
+		return fmt.Errorf("unknown migrate command: %s", args[1])
 	}
-	<-done
 }
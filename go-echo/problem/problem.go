@@ -0,0 +1,94 @@
+// Package problem sends error responses as RFC 7807 problem details
+//
+// Both servers send every error in this form so that clients handle errors the same way whichever route failed
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/keith-cullen/microservice/logging"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+)
+
+const (
+	ContentType = "application/problem+json"
+	typeBlank   = "about:blank" // typeBlank is the type of problems that are described by their status alone
+)
+
+// Problem is the body of an error response
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`   // Detail explains this occurrence of the problem, it is never given for server errors
+	Instance  string       `json:"instance,omitempty"` // Instance is the path of the request
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"` // Errors are the invalid fields of a request that failed validation
+}
+
+// FieldError is an invalid parameter or request body field
+type FieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// New creates the problem of a request that failed with a status code
+func New(r *http.Request, status int, detail string) *Problem {
+	return &Problem{
+		Type:      typeBlank,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: logging.RequestID(r.Context()),
+	}
+}
+
+// Invalid creates the problem of a request whose fields failed validation
+func Invalid(r *http.Request, errs ...FieldError) *Problem {
+	p := New(r, http.StatusBadRequest, "the request is not valid")
+	p.Errors = errs
+	return p
+}
+
+// FromError creates the problem of a request that failed with an error returned by the store
+// Server errors are logged since their details are not sent to the client
+func FromError(r *http.Request, err error) *Problem {
+	var notFound *ent.NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		return New(r, http.StatusNotFound, strings.TrimPrefix(notFound.Error(), "ent: "))
+	case errors.Is(err, store.ErrThingExists):
+		return New(r, http.StatusConflict, store.ErrThingExists.Error())
+	case ent.IsConstraintError(err):
+		return New(r, http.StatusConflict, "the request conflicts with an existing resource")
+	case errors.Is(err, store.ErrInvalidListOptions), errors.Is(err, store.ErrInvalidScope):
+		return New(r, http.StatusBadRequest, err.Error())
+	case ent.IsNotSingular(err):
+		// a lookup by a unique field that matched more than one row means that the data is inconsistent
+		slog.ErrorContext(r.Context(), "request failed, store is inconsistent", "error", err)
+		return New(r, http.StatusInternalServerError, "")
+	default:
+		slog.ErrorContext(r.Context(), "request failed", "error", err)
+		return New(r, http.StatusInternalServerError, "")
+	}
+}
+
+// Write sends the problem as the response
+func (p *Problem) Write(w http.ResponseWriter) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		// a problem only holds strings and integers so this cannot happen
+		http.Error(w, p.Title, p.Status)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(data)
+}
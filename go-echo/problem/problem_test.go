@@ -0,0 +1,119 @@
+package problem
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent/thing"
+)
+
+// openStore returns a store on a new in-memory SQLite database with the schema applied
+func openStore(t *testing.T) *store.Store {
+	t.Helper()
+	dsn := "file:" + strings.ReplaceAll(t.Name(), "/", "_") + "?mode=memory&cache=shared&_fk=1"
+	t.Setenv(config.EnvName("DatabaseDriver"), "sqlite3")
+	t.Setenv(config.EnvName("DatabaseDSN"), dsn)
+	if err := config.Open(""); err != nil {
+		t.Fatal(err)
+	}
+	// the in-memory database only lives while one of its connections is open
+	keep, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keep.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { keep.Close() })
+	if err := store.MigrateUp(context.Background(), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	s, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// failed returns the error of a call that is expected to fail
+func failed[T any](_ T, err error) error {
+	return err
+}
+
+func TestFromError(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+	for _, name := range []string{"alice", "bob"} {
+		if _, err := s.CreateThing(ctx, store.ThingFields{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{"not found", failed(s.GetThingByID(ctx, uuid.New())), http.StatusNotFound, "thing not found"},
+		{"thing exists", failed(s.CreateThing(ctx, store.ThingFields{Name: "bob"})), http.StatusConflict, store.ErrThingExists.Error()},
+		{"constraint", failed(s.Client.Thing.Create().SetName("bob").Save(ctx)), http.StatusConflict, "the request conflicts with an existing resource"},
+		{"invalid list options", failed(s.ListThings(ctx, store.ListOptions{Sort: "colour"})), http.StatusBadRequest, ""},
+		{"invalid scope", func() error {
+			_, _, err := s.CreateAPIKey(ctx, store.APIKeyFields{Scopes: []string{"everything"}})
+			return err
+		}(), http.StatusBadRequest, ""},
+		{"not singular", failed(s.Client.Thing.Query().Where(thing.NameIn("alice", "bob")).Only(ctx)), http.StatusInternalServerError, ""},
+		{"other", fmt.Errorf("failed to get thing: %w", errors.New("connection refused")), http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("the call did not fail")
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/things", nil)
+			p := FromError(r, tt.err)
+			if p.Status != tt.status || p.Title != http.StatusText(tt.status) || p.Type != typeBlank || p.Instance != "/v1/things" {
+				t.Fatalf("got %+v", p)
+			}
+			switch {
+			case tt.status == http.StatusInternalServerError && p.Detail != "":
+				t.Errorf("got detail %q of a server error", p.Detail)
+			case tt.detail != "" && p.Detail != tt.detail:
+				t.Errorf("got detail %q, want %q", p.Detail, tt.detail)
+			case tt.status == http.StatusBadRequest && p.Detail != tt.err.Error():
+				t.Errorf("got detail %q, want %q", p.Detail, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/v1/things", nil)
+	w := httptest.NewRecorder()
+	Invalid(r, FieldError{Field: "name", Detail: "is required"}).Write(w)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("got %d", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != ContentType {
+		t.Errorf("got Content-Type %q", contentType)
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != http.StatusBadRequest || len(p.Errors) != 1 || p.Errors[0] != (FieldError{Field: "name", Detail: "is required"}) {
+		t.Errorf("got %+v", p)
+	}
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     http.Header
+		want       string
+	}{
+		{"direct", "192.0.2.1:1234", nil, "192.0.2.1"},
+		{"IPv4-mapped", "[::ffff:192.0.2.1]:1234", nil, "192.0.2.1"},
+		{"untrusted peer with X-Forwarded-For", "192.0.2.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1"}}, "192.0.2.1"},
+		{"untrusted peer with Forwarded", "192.0.2.1:1234", http.Header{"Forwarded": {"for=198.51.100.1"}}, "192.0.2.1"},
+		{"trusted peer without headers", "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"trusted peer with X-Forwarded-For", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1"}}, "198.51.100.1"},
+		{"chain of trusted proxies", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1, 10.0.0.3", "10.0.0.2"}}, "198.51.100.1"},
+		// the client can prepend any addresses, only the rightmost address that is not a trusted proxy is used
+		{"spoofed X-Forwarded-For", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"203.0.113.7, 198.51.100.1"}}, "198.51.100.1"},
+		{"spoofed trusted address", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"10.0.0.9, 198.51.100.1"}}, "198.51.100.1"},
+		{"all hops trusted", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}}, "10.0.0.3"},
+		{"unparsable hop", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1, garbage"}}, "10.0.0.1"},
+		{"X-Forwarded-For with port", "10.0.0.1:1234", http.Header{"X-Forwarded-For": {"198.51.100.1:5678"}}, "198.51.100.1"},
+		{"Forwarded", "10.0.0.1:1234", http.Header{"Forwarded": {`for=198.51.100.1;proto=https, for="[2001:db8::1]:4711"`}}, "198.51.100.1"},
+		{"Forwarded IPv6", "[2001:db8::2]:1234", http.Header{"Forwarded": {`for="[2001:db9::1]:4711"`}}, "2001:db9::1"},
+		{"Forwarded takes precedence", "10.0.0.1:1234", http.Header{"Forwarded": {"For=198.51.100.1"}, "X-Forwarded-For": {"203.0.113.7"}}, "198.51.100.1"},
+		{"spoofed Forwarded", "10.0.0.1:1234", http.Header{"Forwarded": {"for=203.0.113.7", "for=198.51.100.1"}}, "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.remoteAddr, Header: tt.header}
+			got, err := ClientIP(r, trusted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != netip.MustParseAddr(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPInvalidRemoteAddr(t *testing.T) {
+	if _, err := ClientIP(&http.Request{RemoteAddr: "pipe"}, nil); err == nil {
+		t.Error("got no error")
+	}
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+// gcra applies the generic cell rate algorithm to a request that arrives at now
+// tat is the theoretical arrival time of the next request, the time at which the key would be idle again
+// if requests had arrived at the sustained rate, it is zero or in the past for an idle key
+// The request is allowed if it is no more than a burst of intervals ahead of tat
+// gcra returns the decision and the new tat, which is only stored if the request is allowed
+func gcra(now, tat time.Time, limits Limits) (Result, time.Time) {
+	interval := limits.interval()
+	burstOffset := interval * time.Duration(limits.Burst)
+	if tat.Before(now) {
+		tat = now
+	}
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-burstOffset)
+	if now.Before(allowAt) {
+		return Result{
+			Allowed:    false,
+			Limit:      limits.Burst,
+			Remaining:  0,
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}, tat
+	}
+	return Result{
+		Allowed:    true,
+		Limit:      limits.Burst,
+		Remaining:  int(now.Sub(allowAt) / interval),
+		ResetAfter: newTAT.Sub(now),
+	}, newTAT
+}
@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/config"
+)
+
+const maxKeyLen = 200 // maxKeyLen keeps keys within the size of the key column of the sql store
+
+// ForRequest returns the rate limit key and limits of a request
+// Authenticated requests are limited by their identity, such as an API key or the subject of a bearer token,
+// and anonymous requests by their client IP address
+// A tenant limit replaces the default limits of the identities that it matches
+// A route limit gives each client a token bucket of its own for the route, so a stricter route does not use up the others
+// Route limits are matched by method and route, e.g. "POST /v1/set", or by route alone
+func ForRequest(cfg *config.Config, identity *auth.Identity, clientIP, method, route string) (string, Limits) {
+	key := "ip:" + clientIP
+	limits := Limits{ReqPerSec: cfg.ReqPerSec, Burst: cfg.BurstSize}
+	if identity != nil {
+		key = "id:" + identity.Subject
+		for _, tenant := range cfg.TenantLimits() {
+			if identity.Allowed([]string{tenant.Name}) {
+				limits = Limits{ReqPerSec: tenant.ReqPerSec, Burst: tenant.BurstSize}
+				break
+			}
+		}
+	}
+	if route != "" {
+		for _, limit := range cfg.RouteLimits() {
+			if limit.Name == method+" "+route || limit.Name == route {
+				key = limit.Name + " " + key
+				limits = Limits{ReqPerSec: limit.ReqPerSec, Burst: limit.BurstSize}
+				break
+			}
+		}
+	}
+	if len(key) > maxKeyLen {
+		sum := sha256.Sum256([]byte(key))
+		key = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return key, limits
+}
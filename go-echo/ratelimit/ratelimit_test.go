@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/store"
+)
+
+// testStores are the limiter stores that the tests run against
+var testStores = []struct {
+	name string
+	open func(t *testing.T) LimiterStore
+}{
+	{"memory", func(t *testing.T) LimiterStore { return NewMemoryStore() }},
+	{"redis", openRedisStore},
+	{"sql", openSQLStore},
+}
+
+// openRedisStore returns a store on an in-process Redis server
+func openRedisStore(t *testing.T) LimiterStore {
+	t.Helper()
+	server := miniredis.RunT(t)
+	s, err := NewRedisStore("redis://" + server.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// openSQLStore returns a store on a new in-memory SQLite database with the schema applied
+func openSQLStore(t *testing.T) LimiterStore {
+	t.Helper()
+	dsn := "file:" + strings.ReplaceAll(t.Name(), "/", "_") + "?mode=memory&cache=shared&_fk=1"
+	t.Setenv(config.EnvName("DatabaseDriver"), "sqlite3")
+	t.Setenv(config.EnvName("DatabaseDSN"), dsn)
+	if err := config.Open(""); err != nil {
+		t.Fatal(err)
+	}
+	// the in-memory database only lives while one of its connections is open
+	keep, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keep.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { keep.Close() })
+	if err := store.MigrateUp(context.Background(), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+	return NewSQLStore(db)
+}
+
+// forEachStore runs a test against each limiter store
+func forEachStore(t *testing.T, test func(t *testing.T, s LimiterStore)) {
+	for _, tt := range testStores {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.open(t)
+			t.Cleanup(func() { s.Close() })
+			test(t, s)
+		})
+	}
+}
+
+// allow takes a request from the rate limit of a key and fails the test if the store fails
+func allow(t *testing.T, s LimiterStore, key string, limits Limits) Result {
+	t.Helper()
+	result, err := s.Allow(context.Background(), key, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestBurst(t *testing.T) {
+	limits := Limits{ReqPerSec: 1, Burst: 3}
+	forEachStore(t, func(t *testing.T, s LimiterStore) {
+		for i := 0; i < limits.Burst; i++ {
+			result := allow(t, s, "alice", limits)
+			if !result.Allowed || result.Limit != limits.Burst || result.Remaining != limits.Burst-1-i {
+				t.Fatalf("request %d: got %+v", i, result)
+			}
+		}
+		result := allow(t, s, "alice", limits)
+		if result.Allowed || result.Remaining != 0 {
+			t.Fatalf("got %+v", result)
+		}
+		if result.RetryAfter <= 0 || result.RetryAfter > time.Second {
+			t.Errorf("got retry after %s", result.RetryAfter)
+		}
+		if result.ResetAfter <= 2*time.Second || result.ResetAfter > 3*time.Second {
+			t.Errorf("got reset after %s", result.ResetAfter)
+		}
+	})
+}
+
+func TestRefillAfterRetryAfter(t *testing.T) {
+	limits := Limits{ReqPerSec: 20, Burst: 2}
+	forEachStore(t, func(t *testing.T, s LimiterStore) {
+		for i := 0; i < limits.Burst; i++ {
+			allow(t, s, "alice", limits)
+		}
+		result := allow(t, s, "alice", limits)
+		if result.Allowed {
+			t.Fatalf("got %+v", result)
+		}
+		time.Sleep(result.RetryAfter)
+		if result := allow(t, s, "alice", limits); !result.Allowed {
+			t.Fatalf("after retry after: got %+v", result)
+		}
+		if result := allow(t, s, "alice", limits); result.Allowed {
+			t.Fatalf("after one refill: got %+v", result)
+		}
+	})
+}
+
+func TestKeyIsolation(t *testing.T) {
+	limits := Limits{ReqPerSec: 1, Burst: 1}
+	forEachStore(t, func(t *testing.T, s LimiterStore) {
+		if result := allow(t, s, "alice", limits); !result.Allowed {
+			t.Fatalf("got %+v", result)
+		}
+		if result := allow(t, s, "alice", limits); result.Allowed {
+			t.Fatalf("got %+v", result)
+		}
+		for _, key := range []string{"bob", "POST /v1/set alice"} {
+			if result := allow(t, s, key, limits); !result.Allowed {
+				t.Errorf("%s: got %+v", key, result)
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/keith-cullen/microservice/store"
+)
+
+const (
+	sqlSwapAttempts    = 5                // sqlSwapAttempts bounds the retries when concurrent requests change the same key
+	sqlCleanupInterval = 1 * time.Minute  // sqlCleanupInterval is the time between deletions of idle keys
+	sqlCleanupTimeout  = 10 * time.Second // sqlCleanupTimeout bounds each deletion of idle keys
+)
+
+var errContention = errors.New("rate limit changed by concurrent requests")
+
+// SQLStore keeps the rate limits in the database so that they are shared by the replicas without another service
+// Each decision reads the theoretical arrival time of the key and replaces it only if it has not changed since,
+// so concurrent requests for the same key are retried rather than locked
+// The replicas' clocks must be synchronised since each of them computes the decision with its own clock
+type SQLStore struct {
+	store         *store.Store
+	cleanupTicker *time.Ticker  // cleanupTicker delivers events that trigger the cleanupLoop
+	done          chan struct{} // done is closed to stop the cleanupLoop
+	stopOnce      sync.Once
+}
+
+// NewSQLStore creates a store that keeps the rate limits in the rate_limits table of the database
+func NewSQLStore(store *store.Store) *SQLStore {
+	s := &SQLStore{
+		store:         store,
+		cleanupTicker: time.NewTicker(sqlCleanupInterval),
+		done:          make(chan struct{}),
+	}
+	go s.cleanupLoop()
+	return s
+}
+
+// Allow determines if a request with the given key is allowed or not
+func (s *SQLStore) Allow(ctx context.Context, key string, limits Limits) (Result, error) {
+	for range sqlSwapAttempts {
+		old, found, err := s.store.GetRateLimit(ctx, key)
+		if err != nil {
+			return Result{}, err
+		}
+		result, tat := gcra(time.Now(), time.UnixMicro(old), limits)
+		if !result.Allowed {
+			return result, nil
+		}
+		swapped, err := s.store.SwapRateLimit(ctx, key, old, found, tat.UnixMicro())
+		if err != nil {
+			return Result{}, err
+		}
+		if swapped {
+			return result, nil
+		}
+	}
+	return Result{}, errContention
+}
+
+// Close stops the cleanupLoop and its ticker
+func (s *SQLStore) Close() error {
+	s.stopOnce.Do(func() {
+		s.cleanupTicker.Stop()
+		close(s.done)
+	})
+	return nil
+}
+
+// cleanupLoop periodically deletes the keys that are idle until the store is closed
+func (s *SQLStore) cleanupLoop() {
+	for {
+		select {
+		case <-s.cleanupTicker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), sqlCleanupTimeout)
+			n, err := s.store.DeleteRateLimits(ctx, time.Now().UnixMicro())
+			cancel()
+			if err != nil {
+				slog.Error("rate limiter cleanup failed", "error", err)
+			} else if n > 0 {
+				slog.Debug("rate limiter expired", "keys", n)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,649 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: app.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	App_Get_FullMethodName = "/app.App/get"
+	App_Set_FullMethodName = "/app.App/set"
+)
+
+// AppClient is the client API for App service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// App is the original service, its methods are deprecated in favour of those of Things
+type AppClient interface {
+	// Deprecated: Do not use.
+	Get(ctx context.Context, in *Req, opts ...grpc.CallOption) (*Resp, error)
+	// Deprecated: Do not use.
+	Set(ctx context.Context, in *Req, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
+}
+
+type appClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppClient(cc grpc.ClientConnInterface) AppClient {
+	return &appClient{cc}
+}
+
+// Deprecated: Do not use.
+func (c *appClient) Get(ctx context.Context, in *Req, opts ...grpc.CallOption) (*Resp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, App_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
+func (c *appClient) Set(ctx context.Context, in *Req, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(wrapperspb.BoolValue)
+	err := c.cc.Invoke(ctx, App_Set_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppServer is the server API for App service.
+// All implementations must embed UnimplementedAppServer
+// for forward compatibility.
+//
+// App is the original service, its methods are deprecated in favour of those of Things
+type AppServer interface {
+	// Deprecated: Do not use.
+	Get(context.Context, *Req) (*Resp, error)
+	// Deprecated: Do not use.
+	Set(context.Context, *Req) (*wrapperspb.BoolValue, error)
+	mustEmbedUnimplementedAppServer()
+}
+
+// UnimplementedAppServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAppServer struct{}
+
+func (UnimplementedAppServer) Get(context.Context, *Req) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAppServer) Set(context.Context, *Req) (*wrapperspb.BoolValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (UnimplementedAppServer) mustEmbedUnimplementedAppServer() {}
+func (UnimplementedAppServer) testEmbeddedByValue()             {}
+
+// UnsafeAppServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AppServer will
+// result in compilation errors.
+type UnsafeAppServer interface {
+	mustEmbedUnimplementedAppServer()
+}
+
+func RegisterAppServer(s grpc.ServiceRegistrar, srv AppServer) {
+	// If the following call pancis, it indicates UnimplementedAppServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&App_ServiceDesc, srv)
+}
+
+func _App_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Req)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: App_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Get(ctx, req.(*Req))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Req)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: App_Set_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Set(ctx, req.(*Req))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// App_ServiceDesc is the grpc.ServiceDesc for App service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var App_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "app.App",
+	HandlerType: (*AppServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "get",
+			Handler:    _App_Get_Handler,
+		},
+		{
+			MethodName: "set",
+			Handler:    _App_Set_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "app.proto",
+}
+
+const (
+	Things_List_FullMethodName    = "/app.Things/List"
+	Things_Create_FullMethodName  = "/app.Things/Create"
+	Things_Get_FullMethodName     = "/app.Things/Get"
+	Things_Replace_FullMethodName = "/app.Things/Replace"
+	Things_Update_FullMethodName  = "/app.Things/Update"
+	Things_Delete_FullMethodName  = "/app.Things/Delete"
+)
+
+// ThingsClient is the client API for Things service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Things manages things, which are identified by a UUID and have a unique name
+type ThingsClient interface {
+	List(ctx context.Context, in *ListThingsRequest, opts ...grpc.CallOption) (*ThingList, error)
+	Create(ctx context.Context, in *CreateThingRequest, opts ...grpc.CallOption) (*Thing, error)
+	Get(ctx context.Context, in *GetThingRequest, opts ...grpc.CallOption) (*Thing, error)
+	Replace(ctx context.Context, in *ReplaceThingRequest, opts ...grpc.CallOption) (*Thing, error)
+	Update(ctx context.Context, in *UpdateThingRequest, opts ...grpc.CallOption) (*Thing, error)
+	Delete(ctx context.Context, in *DeleteThingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type thingsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewThingsClient(cc grpc.ClientConnInterface) ThingsClient {
+	return &thingsClient{cc}
+}
+
+func (c *thingsClient) List(ctx context.Context, in *ListThingsRequest, opts ...grpc.CallOption) (*ThingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ThingList)
+	err := c.cc.Invoke(ctx, Things_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsClient) Create(ctx context.Context, in *CreateThingRequest, opts ...grpc.CallOption) (*Thing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Thing)
+	err := c.cc.Invoke(ctx, Things_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsClient) Get(ctx context.Context, in *GetThingRequest, opts ...grpc.CallOption) (*Thing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Thing)
+	err := c.cc.Invoke(ctx, Things_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsClient) Replace(ctx context.Context, in *ReplaceThingRequest, opts ...grpc.CallOption) (*Thing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Thing)
+	err := c.cc.Invoke(ctx, Things_Replace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsClient) Update(ctx context.Context, in *UpdateThingRequest, opts ...grpc.CallOption) (*Thing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Thing)
+	err := c.cc.Invoke(ctx, Things_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsClient) Delete(ctx context.Context, in *DeleteThingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Things_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ThingsServer is the server API for Things service.
+// All implementations must embed UnimplementedThingsServer
+// for forward compatibility.
+//
+// Things manages things, which are identified by a UUID and have a unique name
+type ThingsServer interface {
+	List(context.Context, *ListThingsRequest) (*ThingList, error)
+	Create(context.Context, *CreateThingRequest) (*Thing, error)
+	Get(context.Context, *GetThingRequest) (*Thing, error)
+	Replace(context.Context, *ReplaceThingRequest) (*Thing, error)
+	Update(context.Context, *UpdateThingRequest) (*Thing, error)
+	Delete(context.Context, *DeleteThingRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedThingsServer()
+}
+
+// UnimplementedThingsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedThingsServer struct{}
+
+func (UnimplementedThingsServer) List(context.Context, *ListThingsRequest) (*ThingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedThingsServer) Create(context.Context, *CreateThingRequest) (*Thing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedThingsServer) Get(context.Context, *GetThingRequest) (*Thing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedThingsServer) Replace(context.Context, *ReplaceThingRequest) (*Thing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replace not implemented")
+}
+func (UnimplementedThingsServer) Update(context.Context, *UpdateThingRequest) (*Thing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedThingsServer) Delete(context.Context, *DeleteThingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedThingsServer) mustEmbedUnimplementedThingsServer() {}
+func (UnimplementedThingsServer) testEmbeddedByValue()                {}
+
+// UnsafeThingsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ThingsServer will
+// result in compilation errors.
+type UnsafeThingsServer interface {
+	mustEmbedUnimplementedThingsServer()
+}
+
+func RegisterThingsServer(s grpc.ServiceRegistrar, srv ThingsServer) {
+	// If the following call pancis, it indicates UnimplementedThingsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Things_ServiceDesc, srv)
+}
+
+func _Things_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Things_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).List(ctx, req.(*ListThingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Things_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Things_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Create(ctx, req.(*CreateThingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Things_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Things_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Get(ctx, req.(*GetThingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Things_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceThingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Things_Replace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Replace(ctx, req.(*ReplaceThingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Things_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateThingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Things_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Update(ctx, req.(*UpdateThingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Things_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteThingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Things_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Delete(ctx, req.(*DeleteThingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Things_ServiceDesc is the grpc.ServiceDesc for Things service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Things_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "app.Things",
+	HandlerType: (*ThingsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Things_List_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _Things_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Things_Get_Handler,
+		},
+		{
+			MethodName: "Replace",
+			Handler:    _Things_Replace_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Things_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Things_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "app.proto",
+}
+
+const (
+	APIKeys_List_FullMethodName   = "/app.APIKeys/List"
+	APIKeys_Create_FullMethodName = "/app.APIKeys/Create"
+	APIKeys_Revoke_FullMethodName = "/app.APIKeys/Revoke"
+)
+
+// APIKeysClient is the client API for APIKeys service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// APIKeys manages the API keys, which grant scopes to the clients that send them in the X-API-Key header
+type APIKeysClient interface {
+	List(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*APIKeyList, error)
+	Create(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKeyCreated, error)
+	Revoke(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type aPIKeysClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIKeysClient(cc grpc.ClientConnInterface) APIKeysClient {
+	return &aPIKeysClient{cc}
+}
+
+func (c *aPIKeysClient) List(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*APIKeyList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeyList)
+	err := c.cc.Invoke(ctx, APIKeys_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIKeysClient) Create(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKeyCreated, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeyCreated)
+	err := c.cc.Invoke(ctx, APIKeys_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIKeysClient) Revoke(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, APIKeys_Revoke_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIKeysServer is the server API for APIKeys service.
+// All implementations must embed UnimplementedAPIKeysServer
+// for forward compatibility.
+//
+// APIKeys manages the API keys, which grant scopes to the clients that send them in the X-API-Key header
+type APIKeysServer interface {
+	List(context.Context, *ListAPIKeysRequest) (*APIKeyList, error)
+	Create(context.Context, *CreateAPIKeyRequest) (*APIKeyCreated, error)
+	Revoke(context.Context, *RevokeAPIKeyRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAPIKeysServer()
+}
+
+// UnimplementedAPIKeysServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAPIKeysServer struct{}
+
+func (UnimplementedAPIKeysServer) List(context.Context, *ListAPIKeysRequest) (*APIKeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAPIKeysServer) Create(context.Context, *CreateAPIKeyRequest) (*APIKeyCreated, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAPIKeysServer) Revoke(context.Context, *RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedAPIKeysServer) mustEmbedUnimplementedAPIKeysServer() {}
+func (UnimplementedAPIKeysServer) testEmbeddedByValue()                 {}
+
+// UnsafeAPIKeysServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIKeysServer will
+// result in compilation errors.
+type UnsafeAPIKeysServer interface {
+	mustEmbedUnimplementedAPIKeysServer()
+}
+
+func RegisterAPIKeysServer(s grpc.ServiceRegistrar, srv APIKeysServer) {
+	// If the following call pancis, it indicates UnimplementedAPIKeysServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&APIKeys_ServiceDesc, srv)
+}
+
+func _APIKeys_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeysServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIKeys_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeysServer).List(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIKeys_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeysServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIKeys_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeysServer).Create(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIKeys_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeysServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIKeys_Revoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeysServer).Revoke(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// APIKeys_ServiceDesc is the grpc.ServiceDesc for APIKeys service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var APIKeys_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "app.APIKeys",
+	HandlerType: (*APIKeysServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _APIKeys_List_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _APIKeys_Create_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _APIKeys_Revoke_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "app.proto",
+}
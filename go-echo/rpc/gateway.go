@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/keith-cullen/microservice/problem"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// errInvalidBody is the error of a request body that cannot be decoded, which the handlers describe in the same way
+var errInvalidBody = errors.New("the request body is not a valid JSON object")
+
+// gatewayURLKey is the context key of the URL of a request that is served by the gateway
+type gatewayURLKey struct{}
+
+// appResponse is the response of the endpoints of the App service in openapi.yaml
+type appResponse struct {
+	Message string `json:"message"`
+}
+
+// Gateway serves the REST endpoints bound in app.proto with grpc-gateway
+// It calls the services directly since the requests are authenticated and rate limited by the middlewares of the server
+// Its requests and responses are those of openapi.yaml, like the responses of the handlers that it replaces
+type Gateway struct {
+	mux *runtime.ServeMux
+}
+
+// NewGateway returns the gateway of the services, whose JSON responses have a content type
+func NewGateway(services *Services, contentType string) (*Gateway, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(gatewayErrorHandler),
+		runtime.WithForwardResponseOption(gatewayStatus),
+		runtime.WithForwardResponseRewriter(gatewayResponse),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &gatewayMarshaler{
+			JSONPb: runtime.JSONPb{
+				// the fields are named as in app.proto and those without presence are always sent, as the handlers send them
+				MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitDefaultValues: true},
+				UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+			},
+			contentType: contentType,
+		}),
+	)
+	if err := RegisterAppHandlerServer(context.Background(), mux, services.App); err != nil {
+		return nil, fmt.Errorf("failed to register gateway: %w", err)
+	}
+	if err := RegisterThingsHandlerServer(context.Background(), mux, services.Things); err != nil {
+		return nil, fmt.Errorf("failed to register gateway: %w", err)
+	}
+	if err := RegisterAPIKeysHandlerServer(context.Background(), mux, services.APIKeys); err != nil {
+		return nil, fmt.Errorf("failed to register gateway: %w", err)
+	}
+	return &Gateway{mux: mux}, nil
+}
+
+// Handler returns the handler of a route
+// The responses of deprecated routes, including errors, are marked as they are by their handlers
+func (gateway *Gateway) Handler(route Route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route.Deprecated {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", "</v1/things>; rel=\"successor-version\"")
+		}
+		gateway.mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), gatewayURLKey{}, r.URL)))
+	})
+}
+
+// gatewayMarshaler is the JSON marshaler of the gateway, with the content type of the JSON responses of the handlers
+type gatewayMarshaler struct {
+	runtime.JSONPb
+	contentType string
+}
+
+func (m *gatewayMarshaler) ContentType(any) string {
+	return m.contentType
+}
+
+// Marshal sends no body for the methods that return Empty, whose responses are 204 No Content
+func (m *gatewayMarshaler) Marshal(v any) ([]byte, error) {
+	if _, ok := v.(*emptypb.Empty); ok {
+		return nil, nil
+	}
+	return m.JSONPb.Marshal(v)
+}
+
+// NewDecoder decodes request bodies, a body that cannot be decoded gives the error that the handlers give for it
+func (m *gatewayMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	decoder := m.JSONPb.NewDecoder(r)
+	return runtime.DecoderFunc(func(v any) error {
+		err := decoder.Decode(v)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return errInvalidBody
+		}
+		return err
+	})
+}
+
+// gatewayStatus sends the status and headers of the responses that the handlers do not answer with 200 OK
+func gatewayStatus(ctx context.Context, w http.ResponseWriter, response proto.Message) error {
+	method, _ := runtime.RPCMethod(ctx)
+	switch response := response.(type) {
+	case *emptypb.Empty:
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	case *Thing:
+		if method == Things_Create_FullMethodName {
+			w.Header().Set("Location", fmt.Sprintf("/v1/things/%s", response.GetId()))
+			w.WriteHeader(http.StatusCreated)
+		}
+	case *APIKeyCreated:
+		w.WriteHeader(http.StatusCreated)
+	case *ThingList:
+		if u, ok := ctx.Value(gatewayURLKey{}).(*url.URL); ok && response.NextCursor != nil {
+			w.Header().Set("Link", nextLink(u, response.GetNextCursor()))
+		}
+	}
+	return nil
+}
+
+// Build a Link header value that points to the next page of a list
+func nextLink(u *url.URL, cursor string) string {
+	query := u.Query()
+	query.Set("cursor", cursor)
+	next := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf("<%s>; rel=\"next\"", next.String())
+}
+
+// gatewayResponse converts a response of the App service to the response of its endpoint in openapi.yaml
+func gatewayResponse(ctx context.Context, response proto.Message) (any, error) {
+	switch response := response.(type) {
+	case *Resp:
+		return &appResponse{Message: response.GetValue()}, nil
+	case *wrapperspb.BoolValue:
+		// set only reports success, the message greets the name as the handler of the REST endpoint does
+		var name string
+		if u, ok := ctx.Value(gatewayURLKey{}).(*url.URL); ok {
+			name = u.Query().Get("name")
+		}
+		return &appResponse{Message: fmt.Sprintf("Hello, %s", name)}, nil
+	default:
+		return response, nil
+	}
+}
+
+// gatewayErrorHandler sends the errors of the services as problem details, like the errors of the other endpoints
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	var fieldErrors []problem.FieldError
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fieldErrors = append(fieldErrors, problem.FieldError{Field: violation.GetField(), Detail: violation.GetDescription()})
+			}
+		}
+	}
+	switch {
+	case len(fieldErrors) > 0:
+		problem.Invalid(r, fieldErrors...).Write(w)
+	case st.Code() == codes.Internal, st.Code() == codes.Unknown:
+		// the service has logged the error, its details are not sent to the client
+		problem.New(r, runtime.HTTPStatusFromCode(st.Code()), "").Write(w)
+	default:
+		problem.New(r, runtime.HTTPStatusFromCode(st.Code()), st.Message()).Write(w)
+	}
+}
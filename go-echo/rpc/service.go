@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Services are the implementations of the services of app.proto, which call the same store methods as the REST API
+type Services struct {
+	App     *Service
+	Things  *ThingsService
+	APIKeys *APIKeysService
+}
+
+func NewServices(store *store.Store) *Services {
+	return &Services{
+		App:     &Service{store: store},
+		Things:  &ThingsService{store: store},
+		APIKeys: &APIKeysService{store: store},
+	}
+}
+
+// Service implements the App service of app.proto
+type Service struct {
+	UnimplementedAppServer
+	store *store.Store
+}
+
+func (service *Service) Get(ctx context.Context, req *Req) (*Resp, error) {
+	slog.DebugContext(ctx, "Get", "name", req.GetName())
+	if req.GetName() == "" {
+		return nil, invalidArgument("name", "is required")
+	}
+	if _, err := service.store.GetThing(ctx, req.GetName()); err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return &Resp{Value: fmt.Sprintf("Hello, %s", req.GetName())}, nil
+}
+
+func (service *Service) Set(ctx context.Context, req *Req) (*wrapperspb.BoolValue, error) {
+	slog.DebugContext(ctx, "Set", "name", req.GetName(), "identity", auth.FromContext(ctx).String())
+	if req.GetName() == "" {
+		return nil, invalidArgument("name", "is required")
+	}
+	if err := service.store.SetThing(ctx, req.GetName()); err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return wrapperspb.Bool(true), nil
+}
+
+// invalidArgument returns the error of a request with an invalid field
+// The field is given as a google.rpc.BadRequest detail, as the invalid fields are given in the problem details of the REST API
+func invalidArgument(field, description string) error {
+	st := status.New(codes.InvalidArgument, "the request is not valid")
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// storeError maps an error returned by the store to a gRPC status
+// Internal errors are logged since their details are not sent to the client
+func storeError(ctx context.Context, err error) error {
+	var notFound *ent.NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		return status.Error(codes.NotFound, strings.TrimPrefix(notFound.Error(), "ent: "))
+	case errors.Is(err, store.ErrThingExists):
+		return status.Error(codes.AlreadyExists, store.ErrThingExists.Error())
+	case ent.IsConstraintError(err):
+		return status.Error(codes.AlreadyExists, "the request conflicts with an existing resource")
+	case errors.Is(err, store.ErrInvalidListOptions), errors.Is(err, store.ErrInvalidScope):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ent.IsNotSingular(err):
+		slog.ErrorContext(ctx, "request failed, store is inconsistent", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	default:
+		slog.ErrorContext(ctx, "request failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
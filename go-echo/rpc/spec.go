@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// swaggerJSON is the OpenAPI 2 spec that protoc-gen-openapiv2 generates from app.proto
+//
+//go:embed app.swagger.json
+var swaggerJSON []byte
+
+// Route is a REST endpoint bound to a method of a service by its google.api.http option
+type Route struct {
+	Method     string
+	Path       string
+	Deprecated bool // Deprecated is set if the method is deprecated
+}
+
+// Routes returns the REST endpoints bound in app.proto, which grpc-gateway serves
+func Routes() []Route {
+	var routes []Route
+	services := File_app_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			options := methods.Get(j).Options().(*descriptorpb.MethodOptions)
+			rule, ok := proto.GetExtension(options, annotations.E_Http).(*annotations.HttpRule)
+			if !ok || rule == nil {
+				continue
+			}
+			for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+				route := httpRoute(r)
+				route.Deprecated = options.GetDeprecated()
+				routes = append(routes, route)
+			}
+		}
+	}
+	return routes
+}
+
+// httpRoute returns the REST endpoint of an HTTP rule
+func httpRoute(rule *annotations.HttpRule) Route {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return Route{Method: "GET", Path: pattern.Get}
+	case *annotations.HttpRule_Put:
+		return Route{Method: "PUT", Path: pattern.Put}
+	case *annotations.HttpRule_Post:
+		return Route{Method: "POST", Path: pattern.Post}
+	case *annotations.HttpRule_Delete:
+		return Route{Method: "DELETE", Path: pattern.Delete}
+	case *annotations.HttpRule_Patch:
+		return Route{Method: "PATCH", Path: pattern.Patch}
+	case *annotations.HttpRule_Custom:
+		return Route{Method: pattern.Custom.GetKind(), Path: pattern.Custom.GetPath()}
+	default:
+		return Route{}
+	}
+}
+
+// GetSwagger returns the OpenAPI 3 spec of the REST endpoints bound in app.proto,
+// converted from the OpenAPI 2 spec generated by protoc-gen-openapiv2
+func GetSwagger() (*openapi3.T, error) {
+	var doc openapi2.T
+	if err := json.Unmarshal(swaggerJSON, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse generated OpenAPI spec: %w", err)
+	}
+	spec, err := openapi2conv.ToV3(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert generated OpenAPI spec: %w", err)
+	}
+	return spec, nil
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/ratelimit"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/labstack/echo/v4"
+)
+
+// newVerifier returns the bearer token verifier, or nil if bearer tokens are not required
+func newVerifier(cfg *config.Config) (*auth.Verifier, error) {
+	if !cfg.JWTEnabled() {
+		return nil, nil
+	}
+	return auth.NewVerifier(auth.VerifierConfig{
+		JWKSFile: cfg.JWKSFile,
+		KeyFiles: cfg.JWTKeyFiles,
+		Secret:   cfg.JWTSecret,
+		Issuer:   cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		Leeway:   cfg.JWTLeeway,
+	})
+}
+
+// newAuthenticator returns the authenticator of API keys, bearer tokens and client certificates
+func newAuthenticator(cfg *config.Config, store *store.Store) (*auth.Authenticator, error) {
+	verifier, err := newVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Authenticator{
+		Verifier: verifier,
+		APIKey: func(ctx context.Context, key string) (*auth.Identity, error) {
+			k, err := store.AuthenticateAPIKey(ctx, key)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", auth.ErrInvalidAPIKey, err)
+			}
+			return &auth.Identity{
+				Subject: fmt.Sprintf("apikey:%s", k.ID),
+				Names:   []string{k.Owner},
+				Scopes:  k.Scopes,
+			}, nil
+		},
+		AdminIdentities: func() []string {
+			return config.Get().AdminIdentities
+		},
+	}, nil
+}
+
+// authMiddleware authenticates the request with an API key or bearer token, checks the scope needed by the request
+// and adds the identity to the request context
+func authMiddleware(authenticator *auth.Authenticator, limiter *ratelimit.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			r := ctx.Request()
+			if r.Method == http.MethodOptions {
+				return next(ctx)
+			}
+			identity, err := authenticator.Authenticate(r)
+			if err != nil {
+				slog.InfoContext(r.Context(), "authentication failed", "error", err)
+				// failed attempts are limited by client IP so that credentials cannot be guessed at an unlimited rate
+				if err := rateLimit(ctx, limiter, nil); err != nil {
+					return err
+				}
+				if authenticator.Verifier != nil {
+					ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, auth.Challenge(err, auth.RequiredScope(r)))
+				}
+				return echo.NewHTTPError(auth.Status(err))
+			}
+			if identity != nil {
+				ctx.SetRequest(r.WithContext(auth.NewContext(r.Context(), identity)))
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/keith-cullen/microservice/auth"
+)
+
+// client is the certificate and the headers that a test request is sent with
+type client struct {
+	cert   *x509.Certificate
+	header map[string]string
+}
+
+// clientCert returns a client certificate issued by the CA for a template
+func clientCert(t *testing.T, ca *testCA, template *x509.Certificate) *x509.Certificate {
+	t.Helper()
+	certPEM, _ := ca.issue(t, template)
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// serveAs sends a request with an optional JSON body from a client to a handler
+// The client certificate is given as the verified chain that the TLS handshake would give
+func serveAs(t *testing.T, handler http.Handler, c client, method, target string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	r := httptest.NewRequest(method, target, reader)
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range c.header {
+		r.Header.Set(key, value)
+	}
+	if c.cert != nil {
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{c.cert}}}
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+// createAPIKey creates an API key as an admin and returns its secret
+func createAPIKey(t *testing.T, handler http.Handler, admin client, owner string, scopes ...string) string {
+	t.Helper()
+	w := serveAs(t, handler, admin, http.MethodPost, "/v1/admin/apikeys", map[string]any{"owner": owner, "scopes": scopes})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create API key: got %d: %s", w.Code, w.Body)
+	}
+	return decode[map[string]any](t, w)["key"].(string)
+}
+
+const (
+	testSecret   = "test-secret"
+	testIssuer   = "https://issuer.example.org"
+	testAudience = "microservice"
+)
+
+// jwtEnv adds the configuration that requires the bearer tokens of signToken to a configuration
+func jwtEnv(env map[string]string) map[string]string {
+	env["JWTSecret"] = testSecret
+	env["JWTIssuer"] = testIssuer
+	env["JWTAudience"] = testAudience
+	return env
+}
+
+// signToken returns a bearer token for a subject with scopes that is valid for an hour
+func signToken(t *testing.T, subject string, scopes ...string) string {
+	t.Helper()
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scp: scopes,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestWriteIdentities(t *testing.T) {
+	handler := newTestServer(t, map[string]string{
+		"WriteIdentities": "writer,writer.example.org,spiffe://example.org/writer,writer@example.org,ci",
+		"AdminIdentities": "admin",
+	})
+	ca := newTestCA(t)
+	cert := func(template *x509.Certificate) client {
+		return client{cert: clientCert(t, ca, template)}
+	}
+	admin := cert(&x509.Certificate{Subject: pkix.Name{CommonName: "admin"}})
+	apiKey := func(owner string, scopes ...string) client {
+		return client{header: map[string]string{auth.HeaderAPIKey: createAPIKey(t, handler, admin, owner, scopes...)}}
+	}
+	writer, _ := url.Parse("spiffe://example.org/writer")
+	other := cert(&x509.Certificate{Subject: pkix.Name{CommonName: "other"}})
+	allowedKey := apiKey("ci", auth.ScopeThingsRead, auth.ScopeThingsWrite)
+	tests := []struct {
+		name   string
+		client client
+		method string
+		status int
+	}{
+		{"common name", cert(&x509.Certificate{Subject: pkix.Name{CommonName: "writer"}}), http.MethodPost, http.StatusCreated},
+		{"DNS name", cert(&x509.Certificate{DNSNames: []string{"writer.example.org"}}), http.MethodPost, http.StatusCreated},
+		{"URI", cert(&x509.Certificate{URIs: []*url.URL{writer}}), http.MethodPost, http.StatusCreated},
+		{"email address", cert(&x509.Certificate{EmailAddresses: []string{"writer@example.org"}}), http.MethodPost, http.StatusCreated},
+		{"certificate not allowed", other, http.MethodPost, http.StatusForbidden},
+		{"no certificate", client{}, http.MethodPost, http.StatusUnauthorized},
+		{"read without certificate", client{}, http.MethodGet, http.StatusOK},
+		{"read with a certificate that is not allowed", other, http.MethodGet, http.StatusOK},
+		{"API key of an allowed owner", allowedKey, http.MethodPost, http.StatusCreated},
+		{"API key of another owner", apiKey("other", auth.ScopeThingsWrite), http.MethodPost, http.StatusForbidden},
+		// the API key takes precedence over the client certificate
+		{"API key of an allowed owner with a certificate that is not allowed",
+			client{cert: other.cert, header: allowedKey.header}, http.MethodPost, http.StatusCreated},
+		{"read-only API key of an allowed owner", apiKey("ci", auth.ScopeThingsRead), http.MethodPost, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body any
+			if tt.method == http.MethodPost {
+				body = map[string]any{"name": tt.name}
+			}
+			if w := serveAs(t, handler, tt.client, tt.method, "/v1/things", body); w.Code != tt.status {
+				t.Errorf("got %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
+
+func TestWriteIdentitiesWithBearerTokens(t *testing.T) {
+	handler := newTestServer(t, jwtEnv(map[string]string{"WriteIdentities": "writer"}))
+	bearer := func(subject string, scopes ...string) client {
+		return client{header: map[string]string{"Authorization": "Bearer " + signToken(t, subject, scopes...)}}
+	}
+	// the subject of the token is checked rather than the client certificate
+	other := clientCert(t, newTestCA(t), &x509.Certificate{Subject: pkix.Name{CommonName: "other"}})
+	tests := []struct {
+		name   string
+		client client
+		status int
+	}{
+		{"allowed subject", bearer("writer", auth.ScopeThingsWrite), http.StatusCreated},
+		{"allowed subject with a certificate that is not allowed",
+			client{cert: other, header: bearer("writer", auth.ScopeThingsWrite).header}, http.StatusCreated},
+		{"subject not allowed", bearer("other", auth.ScopeThingsWrite), http.StatusForbidden},
+		{"allowed subject without the write scope", bearer("writer", auth.ScopeThingsRead), http.StatusForbidden},
+		{"no token", client{}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveAs(t, handler, tt.client, http.MethodPost, "/v1/things", map[string]any{"name": tt.name}); w.Code != tt.status {
+				t.Errorf("got %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testCA is a certificate authority that issues the certificates of the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM-encoded certificate for a template, signed by the CA, and its private key
+// The template's serial number, key usage and validity are set if they are not given
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.SerialNumber == nil {
+		if template.SerialNumber, err = rand.Int(rand.Reader, big.NewInt(1<<62)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+	}
+	if template.NotAfter.IsZero() {
+		template.NotAfter = time.Now().Add(90 * 24 * time.Hour)
+	}
+	if template.ExtKeyUsage == nil {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile writes a file, replacing it as Kubernetes and most tools do rather than rewriting it
+func writeFile(t *testing.T, filename string, content []byte) {
+	t.Helper()
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// syncBuffer is a buffer that the log records of background goroutines can be written to
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// captureLogs makes the default logger write to a buffer for the rest of a test
+func captureLogs(t *testing.T) *syncBuffer {
+	t.Helper()
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	buf := &syncBuffer{}
+	slog.SetDefault(slog.New(slog.NewTextHandler(buf, nil)))
+	return buf
+}
+
+// eventually fails a test if a condition does not become true within a few seconds
+func eventually(t *testing.T, condition func() bool, format string, args ...any) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newTestCertReloader writes a certificate for a common name and returns a reloader of its files
+func newTestCertReloader(t *testing.T, ca *testCA, commonName string) (r *CertReloader, certFile, keyFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	certPEM, keyPEM := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: commonName}})
+	writeFile(t, certFile, certPEM)
+	writeFile(t, keyFile, keyPEM)
+	r, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r, certFile, keyFile
+}
+
+// commonName returns the common name of the certificate that a reloader serves
+func commonName(t *testing.T, r *CertReloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert.Leaf.Subject.CommonName
+}
+
+func TestCertReloaderRotation(t *testing.T) {
+	ca := newTestCA(t)
+	r, certFile, keyFile := newTestCertReloader(t, ca, "one")
+	if got := commonName(t, r); got != "one" {
+		t.Fatalf("got the certificate of %q", got)
+	}
+	certPEM, keyPEM := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "two"}})
+	writeFile(t, keyFile, keyPEM)
+	writeFile(t, certFile, certPEM)
+	eventually(t, func() bool { return commonName(t, r) == "two" }, "the rotated certificate was not loaded")
+}
+
+func TestCertReloaderMismatch(t *testing.T) {
+	logs := captureLogs(t)
+	ca := newTestCA(t)
+	r, certFile, keyFile := newTestCertReloader(t, ca, "one")
+	certPEM, keyPEM := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "two"}})
+	// the certificate is replaced before its key, so it does not match the key that is still in place
+	writeFile(t, certFile, certPEM)
+	eventually(t, func() bool { return strings.Contains(logs.String(), "keeping the current certificate") },
+		"the mismatched certificate was not rejected: %s", logs)
+	if got := commonName(t, r); got != "one" {
+		t.Fatalf("got the certificate of %q, want the previous one", got)
+	}
+	writeFile(t, keyFile, keyPEM)
+	eventually(t, func() bool { return commonName(t, r) == "two" }, "the certificate was not loaded with its key")
+}
+
+func TestCertReloaderExpiryWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		validFor time.Duration
+		warning  bool
+	}{
+		{"expires soon", 24 * time.Hour, true},
+		{"valid", 90 * 24 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+			ca := newTestCA(t)
+			dir := t.TempDir()
+			certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+			notAfter := time.Now().Add(tt.validFor).Truncate(time.Second)
+			certPEM, keyPEM := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "server"}, NotAfter: notAfter})
+			writeFile(t, certFile, certPEM)
+			writeFile(t, keyFile, keyPEM)
+			r, err := NewCertReloader(certFile, keyFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			if !r.NotAfter().Equal(notAfter) {
+				t.Errorf("got NotAfter %s, want %s", r.NotAfter(), notAfter)
+			}
+			if warning := strings.Contains(logs.String(), "certificate expires soon"); warning != tt.warning {
+				t.Errorf("got the warning %t, want %t: %s", warning, tt.warning, logs)
+			}
+		})
+	}
+}
+
+func TestNewCertReloaderInvalid(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	certPEM, _ := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "one"}})
+	_, keyPEM := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "two"}})
+	writeFile(t, certFile, certPEM)
+	writeFile(t, keyFile, keyPEM)
+	if _, err := NewCertReloader(certFile, keyFile); err == nil {
+		t.Error("got no error for a certificate that does not match its key")
+	}
+	if _, err := NewCertReloader(filepath.Join(dir, "missing.pem"), keyFile); err == nil {
+		t.Error("got no error for a missing certificate")
+	}
+}
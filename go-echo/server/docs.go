@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/keith-cullen/microservice/api"
+	"github.com/keith-cullen/microservice/docs"
+)
+
+const (
+	specJSONPath = "/openapi.json"
+	specYAMLPath = "/openapi.yaml"
+	docsPath     = "/docs/"
+)
+
+// withDocs serves the OpenAPI spec and Swagger UI ahead of the API handler so that they are public, if they are enabled
+func withDocs(next http.Handler, enabled bool) (http.Handler, error) {
+	if !enabled {
+		return next, nil
+	}
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	d, err := docs.New(spec)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", next)
+	mux.Handle("GET "+specJSONPath, withRoute(specJSONPath, http.HandlerFunc(d.JSON)))
+	mux.Handle("GET "+specYAMLPath, withRoute(specYAMLPath, http.HandlerFunc(d.YAML)))
+	mux.Handle("GET "+docsPath, withRoute(docsPath, http.StripPrefix(docsPath, http.HandlerFunc(d.UI))))
+	return mux, nil
+}
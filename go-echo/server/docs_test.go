@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	// the docs are public even if every API request needs a bearer token
+	handler := newTestServer(t, jwtEnv(map[string]string{"APIDocs": "true"}))
+	tests := []struct {
+		target      string
+		contentType string
+		contains    string
+	}{
+		{specYAMLPath, "application/yaml", "/v1/things/{id}:"},
+		{specJSONPath, "application/json", `"/v1/things/{id}":`},
+		{docsPath, "text/html; charset=utf-8", "swagger-ui"},
+		// the initializer that points Swagger UI at the spec of the service replaces the one of the distribution
+		{docsPath + "swagger-initializer.js", "text/javascript; charset=utf-8", `url: "../openapi.json"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := serve(t, handler, http.MethodGet, tt.target, nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d: %s", w.Code, w.Body)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.contentType {
+				t.Errorf("got Content-Type %q, want %q", contentType, tt.contentType)
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("got a body without %q", tt.contains)
+			}
+		})
+	}
+}
+
+func TestDocsDisabled(t *testing.T) {
+	handler := newTestServer(t, nil)
+	for _, target := range []string{specYAMLPath, specJSONPath, docsPath} {
+		if w := serve(t, handler, http.MethodGet, target, nil); w.Code != http.StatusNotFound {
+			t.Errorf("%s: got %d, want %d", target, w.Code, http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"regexp"
+
+	"github.com/keith-cullen/microservice/rpc"
+	"github.com/labstack/echo/v4"
+)
+
+var gatewayParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// registerGateway serves the REST endpoints bound in app.proto with grpc-gateway in place of the handlers generated from openapi.yaml
+func registerGateway(echoServer *echo.Echo, services *rpc.Services) error {
+	gateway, err := rpc.NewGateway(services, echo.MIMEApplicationJSONCharsetUTF8)
+	if err != nil {
+		return err
+	}
+	for _, route := range rpc.Routes() {
+		echoServer.Add(route.Method, gatewayParam.ReplaceAllString(route.Path, ":$1"), echo.WrapHandler(gateway.Handler(route)))
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/logging"
+	"github.com/keith-cullen/microservice/ratelimit"
+	"github.com/keith-cullen/microservice/rpc"
+	"github.com/keith-cullen/microservice/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const grpcContentType = "application/grpc"
+
+// grpcScopes are the scopes needed by the methods of the services of app.proto
+// The methods of the other services, such as health and reflection, are not authenticated or rate limited
+var grpcScopes = map[string]string{
+	rpc.App_Get_FullMethodName:        auth.ScopeThingsRead,
+	rpc.App_Set_FullMethodName:        auth.ScopeThingsWrite,
+	rpc.Things_List_FullMethodName:    auth.ScopeThingsRead,
+	rpc.Things_Create_FullMethodName:  auth.ScopeThingsWrite,
+	rpc.Things_Get_FullMethodName:     auth.ScopeThingsRead,
+	rpc.Things_Replace_FullMethodName: auth.ScopeThingsWrite,
+	rpc.Things_Update_FullMethodName:  auth.ScopeThingsWrite,
+	rpc.Things_Delete_FullMethodName:  auth.ScopeThingsWrite,
+	rpc.APIKeys_List_FullMethodName:   auth.ScopeAPIKeysAdmin,
+	rpc.APIKeys_Create_FullMethodName: auth.ScopeAPIKeysAdmin,
+	rpc.APIKeys_Revoke_FullMethodName: auth.ScopeAPIKeysAdmin,
+}
+
+// newGRPCServer returns the gRPC server of the services of app.proto with reflection and the standard health service
+// The interceptors authenticate and rate limit the calls like the HTTP middlewares
+func newGRPCServer(services *rpc.Services, authenticator *auth.Authenticator, limiter *ratelimit.Limiter) (*grpc.Server, *grpchealth.Server) {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		grpcIdentityInterceptor,
+		grpcAuthInterceptor(authenticator, limiter),
+		grpcWriteInterceptor,
+		grpcRateLimitInterceptor(limiter),
+	))
+	rpc.RegisterAppServer(grpcServer, services.App)
+	rpc.RegisterThingsServer(grpcServer, services.Things)
+	rpc.RegisterAPIKeysServer(grpcServer, services.APIKeys)
+	health := grpchealth.NewServer()
+	for _, service := range []string{rpc.App_ServiceDesc.ServiceName, rpc.Things_ServiceDesc.ServiceName, rpc.APIKeys_ServiceDesc.ServiceName} {
+		health.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(grpcServer, health)
+	reflection.Register(grpcServer)
+	return grpcServer, health
+}
+
+// withGRPC serves the HTTP/2 requests with a gRPC content type with the gRPC server and the others with the API handler
+// so that both are served on the same listener, whose TLS connections negotiate HTTP/2 with ALPN
+func withGRPC(api http.Handler, grpcServer *grpc.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		api.ServeHTTP(w, r)
+	})
+}
+
+// newGRPCHTTPServer returns the separate gRPC listener, or nil if gRPC is served on the API listener
+// The calls are logged and traced like the HTTP requests
+func newGRPCHTTPServer(grpcAddr string, grpcServer *grpc.Server) *http.Server {
+	if grpcAddr == "" {
+		return nil
+	}
+	return &http.Server{
+		Addr:           grpcAddr,
+		Handler:        tracing.Middleware(logging.Middleware(grpcServer)),
+		ReadTimeout:    timeout,
+		WriteTimeout:   timeout,
+		MaxHeaderBytes: maxHeaderBytes,
+	}
+}
+
+// startGRPCServer serves gRPC on the separate listener in the background, with TLS unless tlsConfig is nil
+func startGRPCServer(grpcHTTPServer *http.Server, tlsConfig *tls.Config) {
+	if grpcHTTPServer == nil {
+		return
+	}
+	slog.Info("grpc server listening", "addr", grpcHTTPServer.Addr)
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			// the HTTP/2 protocol that gRPC needs is added to the cloned configuration
+			grpcHTTPServer.TLSConfig = tlsConfig.Clone()
+			err = grpcHTTPServer.ListenAndServeTLS("", "")
+		} else {
+			err = grpcHTTPServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			slog.Error("grpc server failed", "error", err)
+		}
+	}()
+}
+
+// stopGRPCServer stops the separate gRPC listener, waiting until ctx is done for the in-flight calls to finish
+func stopGRPCServer(ctx context.Context, grpcHTTPServer *http.Server) error {
+	if grpcHTTPServer == nil {
+		return nil
+	}
+	return grpcHTTPServer.Shutdown(ctx)
+}
+
+// grpcRequest returns a request with the metadata and peer of a call
+// so that the call can be authenticated and rate limited by the same functions as the HTTP requests
+func grpcRequest(ctx context.Context) *http.Request {
+	r := &http.Request{Header: http.Header{}}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		if !strings.HasSuffix(key, "-bin") {
+			r.Header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		r.RemoteAddr = p.Addr.String()
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			r.TLS = &tlsInfo.State
+		}
+	}
+	return r
+}
+
+// grpcCode returns the gRPC status code of an authentication error
+func grpcCode(err error) codes.Code {
+	if errors.Is(err, auth.ErrInsufficientScope) {
+		return codes.PermissionDenied
+	}
+	return codes.Unauthenticated
+}
+
+// grpcIdentityInterceptor adds the identity given by the client certificate to the call context
+func grpcIdentityInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if _, ok := grpcScopes[info.FullMethod]; !ok {
+		return handler(ctx, req)
+	}
+	if identity := auth.FromRequest(grpcRequest(ctx)); identity != nil {
+		ctx = auth.NewContext(ctx, identity)
+	}
+	return handler(ctx, req)
+}
+
+// grpcAuthInterceptor authenticates the call with an API key or bearer token in its metadata, checks the scope needed by the method
+// and adds the identity to the call context
+func grpcAuthInterceptor(authenticator *auth.Authenticator, limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		scope, ok := grpcScopes[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		identity, err := authenticator.AuthenticateHeader(ctx, grpcRequest(ctx).Header, scope)
+		if err != nil {
+			slog.InfoContext(ctx, "authentication failed", "error", err)
+			// failed attempts are limited by client IP so that credentials cannot be guessed at an unlimited rate
+			if err := grpcRateLimit(ctx, limiter, nil, info.FullMethod); err != nil {
+				return nil, err
+			}
+			if authenticator.Verifier != nil {
+				grpc.SetHeader(ctx, metadata.Pairs("www-authenticate", auth.Challenge(err, scope)))
+			}
+			return nil, status.Error(grpcCode(err), err.Error())
+		}
+		if identity != nil {
+			ctx = auth.NewContext(ctx, identity)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// grpcWriteInterceptor rejects calls that change things from clients that are not allowed to write
+// It runs after authentication so that the identity given by an API key or bearer token is checked,
+// rather than the identity given by the client certificate
+func grpcWriteInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if grpcScopes[info.FullMethod] != auth.ScopeThingsWrite {
+		return handler(ctx, req)
+	}
+	identity := auth.FromContext(ctx)
+	if !identity.Allowed(config.Get().WriteIdentities) {
+		slog.InfoContext(ctx, "write denied", "identity", identity.String())
+		code := codes.PermissionDenied
+		if identity == nil {
+			code = codes.Unauthenticated
+		}
+		return nil, status.Error(code, "write denied")
+	}
+	return handler(ctx, req)
+}
+
+// grpcRateLimitInterceptor rejects calls from clients that have exceeded their rate limit
+func grpcRateLimitInterceptor(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := grpcScopes[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+		if err := grpcRateLimit(ctx, limiter, auth.FromContext(ctx), info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// grpcRateLimit takes a call from the rate limit of its client and method, which route limits name as e.g. "POST /app.App/set",
+// sends the rate limit headers as header metadata and returns an error if the limit has been exceeded or the client cannot be identified
+func grpcRateLimit(ctx context.Context, limiter *ratelimit.Limiter, identity *auth.Identity, method string) error {
+	ip, err := ratelimit.ClientIP(grpcRequest(ctx), config.Get().TrustedProxyPrefixes())
+	if err != nil {
+		slog.ErrorContext(ctx, "unable to determine client IP", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+	key, limits := ratelimit.ForRequest(config.Get(), identity, ip.String(), http.MethodPost, method)
+	result := limiter.Allow(ctx, key, limits)
+	header := http.Header{}
+	result.SetHeaders(header)
+	md := metadata.MD{}
+	for key, values := range header {
+		md.Append(key, values...)
+	}
+	grpc.SetHeader(ctx, md)
+	if !result.Allowed {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bufconnListener is an in-memory listener whose connections come from a client IP
+// The connections of bufconn have no IP address, which the calls are rate limited by
+type bufconnListener struct {
+	*bufconn.Listener
+	remoteAddr net.Addr
+}
+
+func (l bufconnListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return remoteAddrConn{Conn: conn, remoteAddr: l.remoteAddr}, nil
+}
+
+type remoteAddrConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c remoteAddrConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// newGRPCTestClient serves the gRPC server of a test server on an in-memory listener and returns a client of it
+func newGRPCTestClient(t *testing.T, server *Server) *grpc.ClientConn {
+	t.Helper()
+	l := bufconnListener{
+		Listener:   bufconn.Listen(1 << 20),
+		remoteAddr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234},
+	}
+	go server.grpcServer.Serve(l)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// withToken returns a context whose calls carry a bearer token for a subject with scopes
+func withToken(t *testing.T, subject string, scopes ...string) context.Context {
+	t.Helper()
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+signToken(t, subject, scopes...))
+}
+
+func TestGRPCAuth(t *testing.T) {
+	conn := newGRPCTestClient(t, openTestServer(t, jwtEnv(map[string]string{"WriteIdentities": "writer"})))
+	client, things, apiKeys := rpc.NewAppClient(conn), rpc.NewThingsClient(conn), rpc.NewAPIKeysClient(conn)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context) error
+		code codes.Code
+	}{
+		{"set without a token", context.Background(), callSet(client, "bob"), codes.Unauthenticated},
+		{"set without the write scope", withToken(t, "writer", auth.ScopeThingsRead), callSet(client, "bob"), codes.PermissionDenied},
+		{"set by a subject that may not write", withToken(t, "other", auth.ScopeThingsWrite), callSet(client, "bob"), codes.PermissionDenied},
+		{"set", withToken(t, "writer", auth.ScopeThingsWrite), callSet(client, "bob"), codes.OK},
+		{"get without a token", context.Background(), callGet(client, "bob"), codes.Unauthenticated},
+		{"get without the read scope", withToken(t, "other", auth.ScopeThingsWrite), callGet(client, "bob"), codes.PermissionDenied},
+		// the write identities do not restrict reads
+		{"get", withToken(t, "other", auth.ScopeThingsRead), callGet(client, "bob"), codes.OK},
+		// the methods of the Things and APIKeys services need the scopes of their REST endpoints
+		{"create thing by a subject that may not write", withToken(t, "other", auth.ScopeThingsWrite), callCreate(things, "alice"), codes.PermissionDenied},
+		{"create thing", withToken(t, "writer", auth.ScopeThingsWrite), callCreate(things, "alice"), codes.OK},
+		{"create thing that exists", withToken(t, "writer", auth.ScopeThingsWrite), callCreate(things, "alice"), codes.AlreadyExists},
+		{"create thing without a name", withToken(t, "writer", auth.ScopeThingsWrite), callCreate(things, ""), codes.InvalidArgument},
+		{"list things", withToken(t, "other", auth.ScopeThingsRead), callList(things), codes.OK},
+		{"list API keys without the admin scope", withToken(t, "writer", auth.ScopeThingsRead, auth.ScopeThingsWrite), callListAPIKeys(apiKeys), codes.PermissionDenied},
+		{"list API keys", withToken(t, "admin", auth.ScopeAPIKeysAdmin), callListAPIKeys(apiKeys), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call(tt.ctx)); code != tt.code {
+				t.Errorf("got %s, want %s", code, tt.code)
+			}
+		})
+	}
+}
+
+// callSet returns a call of the Set method for a name
+func callSet(client rpc.AppClient, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.Set(ctx, &rpc.Req{Name: name})
+		return err
+	}
+}
+
+// callGet returns a call of the Get method for a name
+func callGet(client rpc.AppClient, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.Get(ctx, &rpc.Req{Name: name})
+		return err
+	}
+}
+
+// callCreate returns a call of the Create method of the Things service for a name
+func callCreate(client rpc.ThingsClient, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.Create(ctx, &rpc.CreateThingRequest{Thing: &rpc.ThingInput{Name: name}})
+		return err
+	}
+}
+
+// callList returns a call of the List method of the Things service
+func callList(client rpc.ThingsClient) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.List(ctx, &rpc.ListThingsRequest{})
+		return err
+	}
+}
+
+// callListAPIKeys returns a call of the List method of the APIKeys service
+func callListAPIKeys(client rpc.APIKeysClient) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := client.List(ctx, &rpc.ListAPIKeysRequest{})
+		return err
+	}
+}
+
+func TestGRPCRateLimit(t *testing.T) {
+	client := rpc.NewAppClient(newGRPCTestClient(t, openTestServer(t, map[string]string{"ReqPerSec": "1", "BurstSize": "2"})))
+	for i, want := range []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted} {
+		var header metadata.MD
+		_, err := client.Set(context.Background(), &rpc.Req{Name: "bob"}, grpc.Header(&header))
+		if code := status.Code(err); code != want {
+			t.Fatalf("call %d: got %s, want %s", i, code, want)
+		}
+		// the rate limit is given in the header metadata as it is given in the headers of HTTP responses
+		if got := header.Get("ratelimit-limit"); len(got) != 1 || got[0] != "2" {
+			t.Errorf("call %d: got ratelimit-limit %q", i, got)
+		}
+	}
+}
+
+func TestGRPCHealth(t *testing.T) {
+	server := openTestServer(t, nil)
+	client := healthpb.NewHealthClient(newGRPCTestClient(t, server))
+	// the health service is neither authenticated nor rate limited
+	for _, service := range []string{"", rpc.App_ServiceDesc.ServiceName} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("service %q: got %v, %v, want serving", service, resp, err)
+		}
+	}
+	// the health service stops serving as the readiness check starts failing when the server drains
+	server.grpcHealth.Shutdown()
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: rpc.App_ServiceDesc.ServiceName})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("got %v, %v, want not serving", resp, err)
+	}
+}
+
+// TestGRPCOnAPIListener checks that gRPC and REST are served on the same TLS listener, which negotiates HTTP/2 with ALPN
+func TestGRPCOnAPIListener(t *testing.T) {
+	server := openTestServer(t, nil)
+	s := httptest.NewUnstartedServer(server.httpServer.Handler)
+	s.EnableHTTP2 = true
+	s.StartTLS()
+	defer s.Close()
+	conn, err := grpc.NewClient(s.Listener.Addr().String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: s.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := rpc.NewAppClient(conn).Set(context.Background(), &rpc.Req{Name: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Client().Get(s.URL + "/v1/get?name=bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ProtoMajor != 2 {
+		t.Errorf("got %s over %s, want the thing set by gRPC over HTTP/2", resp.Status, resp.Proto)
+	}
+}
+
+// TestGRPCOnInsecureAPIListener checks that gRPC and REST are served on the same listener without TLS,
+// which accepts HTTP/2 with prior knowledge
+func TestGRPCOnInsecureAPIListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	server := openTestServer(t, map[string]string{"Addr": addr})
+	started := make(chan error, 1)
+	go func() { started <- server.Start(true) }()
+	defer func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := <-started; err != nil {
+			t.Error(err)
+		}
+	}()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// the client waits for the listener
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := rpc.NewAppClient(conn).Set(ctx, &rpc.Req{Name: "bob"}, grpc.WaitForReady(true)); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get("http://" + addr + "/v1/get?name=bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got %s, want the thing set by gRPC", resp.Status)
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHealthWhileDraining(t *testing.T) {
+	server := openTestServer(t, map[string]string{"ShutdownDelay": "1s"})
+	handler := server.httpServer.Handler
+	if w := serve(t, handler, http.MethodGet, readyzPath, nil); w.Code != http.StatusOK {
+		t.Fatalf("readyz: got %d: %s", w.Code, w.Body)
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- server.Stop() }()
+	// the readiness check fails as soon as the drain begins, while the server keeps serving for the shutdown delay
+	eventually(t, func() bool {
+		return serve(t, handler, http.MethodGet, readyzPath, nil).Code == http.StatusServiceUnavailable
+	}, "readyz did not fail while draining")
+	w := serve(t, handler, http.MethodGet, readyzPath, nil)
+	if checks := decode[Readiness](t, w).Checks; checks["draining"].Status != statusFail || checks["database"].Status != statusOK {
+		t.Errorf("readyz: got checks %+v", checks)
+	}
+	if w := serve(t, handler, http.MethodGet, healthzPath, nil); w.Code != http.StatusOK {
+		t.Errorf("healthz: got %d while draining: %s", w.Code, w.Body)
+	}
+	select {
+	case err := <-stopped:
+		t.Fatalf("the server stopped before the shutdown delay: %v", err)
+	default:
+	}
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server did not stop")
+	}
+}
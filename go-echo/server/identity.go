@@ -0,0 +1,40 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/labstack/echo/v4"
+)
+
+// identityMiddleware adds the identity given by the client certificate to the request context
+func identityMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		r := ctx.Request()
+		if identity := auth.FromRequest(r); identity != nil {
+			ctx.SetRequest(r.WithContext(auth.NewContext(r.Context(), identity)))
+		}
+		return next(ctx)
+	}
+}
+
+// writeMiddleware rejects requests that change things from clients that are not allowed to write
+// It runs after authentication so that the identity given by an API key or bearer token is checked,
+// rather than the identity given by the client certificate
+func writeMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		r := ctx.Request()
+		identity := auth.FromContext(r.Context())
+		if auth.RequiredScope(r) == auth.ScopeThingsWrite && !identity.Allowed(config.Get().WriteIdentities) {
+			slog.InfoContext(r.Context(), "write denied", "identity", identity.String())
+			status := http.StatusForbidden
+			if identity == nil {
+				status = http.StatusUnauthorized
+			}
+			return echo.NewHTTPError(status)
+		}
+		return next(ctx)
+	}
+}
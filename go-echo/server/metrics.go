@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/keith-cullen/microservice/metrics"
+	"github.com/keith-cullen/microservice/tracing"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	metricsPath   = "/metrics"
+	notFoundRoute = "/*"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// withMetrics records the metrics of the requests handled by the API handler
+// and serves the metrics on the API listener unless they have a listener of their own
+func withMetrics(api http.Handler, metricsAddr string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", metrics.Middleware(api))
+	if metricsAddr == "" {
+		mux.Handle("GET "+metricsPath, metrics.Handler())
+	}
+	return mux
+}
+
+// newMetricsServer returns the admin listener that serves the metrics, or nil if they are served by the API listener
+func newMetricsServer(metricsAddr string) *http.Server {
+	if metricsAddr == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET "+metricsPath, metrics.Handler())
+	return &http.Server{
+		Addr:           metricsAddr,
+		Handler:        mux,
+		ReadTimeout:    timeout,
+		WriteTimeout:   timeout,
+		MaxHeaderBytes: maxHeaderBytes,
+	}
+}
+
+// startMetricsServer serves the metrics on the admin listener in the background
+func startMetricsServer(metricsServer *http.Server) {
+	if metricsServer == nil {
+		return
+	}
+	slog.Info("metrics server listening", "addr", metricsServer.Addr)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("metrics server failed", "error", err)
+		}
+	}()
+}
+
+// stopMetricsServer stops the admin listener, a scrape that is in progress has until the request timeout to finish
+func stopMetricsServer(metricsServer *http.Server) error {
+	if metricsServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return metricsServer.Shutdown(ctx)
+}
+
+// routeTemplate returns the path of the route that a request matched, empty if it only matched the not found route
+// Path parameters are written as in openapi.yaml so that the routes match those of go-nethttp
+func routeTemplate(ctx echo.Context) string {
+	route := ctx.Path()
+	if route == notFoundRoute {
+		return ""
+	}
+	return pathParam.ReplaceAllString(route, "{$1}")
+}
+
+// routeMiddleware labels the metrics and names the span of a request after the path of the route that it matched
+func routeMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		// requests that only match the not found route keep the unmatched label
+		if route := routeTemplate(ctx); route != "" {
+			metrics.SetRoute(ctx.Request().Context(), route)
+			tracing.SetRoute(ctx.Request().Context(), ctx.Request().Method, route)
+		}
+		return next(ctx)
+	}
+}
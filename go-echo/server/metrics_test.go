@@ -0,0 +1,68 @@
+package server
+
+import (
+	"bufio"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// metricValue returns the value of a series that the server exposes, zero if it has no samples
+func metricValue(t *testing.T, handler http.Handler, series string) float64 {
+	t.Helper()
+	w := serve(t, handler, http.MethodGet, metricsPath, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("metrics: got %d: %s", w.Code, w.Body)
+	}
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), series+" "); ok {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return v
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	handler := newTestServer(t, nil)
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		labels string
+	}{
+		{"route", http.MethodPost, "/v1/things", map[string]any{"name": "bob"}, `method="POST",route="/v1/things",status="201"`},
+		// the path parameter does not create a series of its own
+		{"route with a path parameter", http.MethodGet, "/v1/things/6f1f1f3c-4d4e-4c1a-9a39-1b2b3c4d5e6f", nil, `method="GET",route="/v1/things/{id}",status="404"`},
+		{"unmatched path", http.MethodGet, "/v1/unknown/42", nil, `method="GET",route="unmatched",status="404"`},
+		{"health endpoint", http.MethodGet, healthzPath, nil, `method="GET",route="/healthz",status="200"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := "microservice_http_requests_total{" + tt.labels + "}"
+			durations := "microservice_http_request_duration_seconds_count{" + tt.labels + "}"
+			before, beforeDurations := metricValue(t, handler, requests), metricValue(t, handler, durations)
+			serve(t, handler, tt.method, tt.target, tt.body)
+			if got := metricValue(t, handler, requests) - before; got != 1 {
+				t.Errorf("%s increased by %v, want 1", requests, got)
+			}
+			if got := metricValue(t, handler, durations) - beforeDurations; got != 1 {
+				t.Errorf("%s increased by %v, want 1", durations, got)
+			}
+		})
+	}
+}
+
+func TestMetricsOnOwnListener(t *testing.T) {
+	handler := newTestServer(t, map[string]string{"MetricsAddr": "127.0.0.1:0"})
+	// the metrics are only served by the admin listener, so the path is an unknown API route
+	if w := serve(t, handler, http.MethodGet, metricsPath, nil); w.Code != http.StatusNotFound {
+		t.Errorf("got %d, want the metrics not to be served by the API listener", w.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/labstack/echo/v4"
+)
+
+// invalidParam begins the message of the errors of the generated wrappers for parameters that cannot be parsed
+const invalidParam = "Invalid format for parameter "
+
+// errorHandler is the echo.HTTPErrorHandler, it sends the errors returned by the middleware and by echo itself,
+// such as unknown methods and path parameters that cannot be bound, as problem details
+// The message of a client error is its detail unless it only repeats the status
+func errorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		return
+	}
+	r := ctx.Request()
+	var p *problem.Problem
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		detail, _ := httpErr.Message.(string)
+		if param, ok := strings.CutPrefix(detail, invalidParam); ok {
+			// the generated wrappers describe the parse error in terms of Go types, so only the parameter is named
+			param, _, _ = strings.Cut(param, ":")
+			p = problem.Invalid(r, problem.FieldError{Field: param, Detail: "has an invalid format"})
+		} else {
+			if httpErr.Code >= http.StatusInternalServerError || detail == http.StatusText(httpErr.Code) {
+				detail = ""
+			}
+			p = problem.New(r, httpErr.Code, detail)
+		}
+	} else {
+		p = problem.FromError(r, err)
+	}
+	p.Write(ctx.Response())
+}
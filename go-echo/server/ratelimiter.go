@@ -0,0 +1,59 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/ratelimit"
+	"github.com/labstack/echo/v4"
+)
+
+// rateLimitMiddleware rejects requests from clients that have exceeded their rate limit
+// It is used instead of middleware.RateLimiter so that the limits can be shared by the replicas,
+// and runs after authentication so that authenticated clients are limited by their identity
+func rateLimitMiddleware(limiter *ratelimit.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if err := rateLimit(ctx, limiter, auth.FromContext(ctx.Request().Context())); err != nil {
+				return err
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// rateLimit takes a request from the rate limit of its client and route
+// and returns an error if the limit has been exceeded or the client cannot be identified
+func rateLimit(ctx echo.Context, limiter *ratelimit.Limiter, identity *auth.Identity) error {
+	ip := ctx.RealIP()
+	if ip == "" {
+		return echo.ErrInternalServerError
+	}
+	r := ctx.Request()
+	key, limits := ratelimit.ForRequest(config.Get(), identity, ip, r.Method, routeTemplate(ctx))
+	result := limiter.Allow(r.Context(), key, limits)
+	result.SetHeaders(ctx.Response().Header())
+	if !result.Allowed {
+		return echo.NewHTTPError(http.StatusTooManyRequests)
+	}
+	return nil
+}
+
+// clientIP is the echo.IPExtractor that gives ctx.RealIP, it only trusts the forwarding headers of the trusted proxies
+// The client IP is empty if the remote address cannot be parsed
+func clientIP(r *http.Request) string {
+	ip, err := ratelimit.ClientIP(r, config.Get().TrustedProxyPrefixes())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "unable to determine client IP", "error", err)
+		return ""
+	}
+	return ip.String()
+}
+
+// allowOrigin reports whether a CORS request origin is one of the allowed origins
+func allowOrigin(corsOrigins []string, origin string) bool {
+	return slices.Contains(corsOrigins, "*") || slices.Contains(corsOrigins, origin)
+}
@@ -2,18 +2,26 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/keith-cullen/microservice/api"
 	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/logging"
+	"github.com/keith-cullen/microservice/ratelimit"
+	"github.com/keith-cullen/microservice/rpc"
 	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/tracing"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"golang.org/x/time/rate"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 )
 
 const (
@@ -21,41 +29,133 @@ const (
 	maxHeaderBytes = 4096
 )
 
+// ErrShutdownTimeout is returned by Stop if in-flight requests did not finish within the shutdown timeout
+var ErrShutdownTimeout = errors.New("in-flight requests did not finish before the shutdown timeout")
+
 type Server struct {
-	httpServer http.Server
+	httpServer      http.Server
+	echo            *echo.Echo   // echo routes the requests of the API
+	metricsServer   *http.Server // metricsServer is nil if the metrics are served by httpServer
+	health          *health
+	shutdownDelay   time.Duration
+	shutdownTimeout time.Duration
+	rateLimiter     *ratelimit.Limiter
+	grpcServer      *grpc.Server
+	grpcHealth      *grpchealth.Server
+	grpcHTTPServer  *http.Server // grpcHTTPServer is nil if gRPC is served by httpServer
 }
 
 func New(store *store.Store) (*Server, error) {
-	addr := config.Get(config.AddrKey)
-	corsOrigin := config.Get(config.CorsOriginKey)
-	reqPerSec, err := strconv.ParseUint(config.Get(config.ReqPerSecKey), 10, 64)
+	cfg := config.Get()
+	handler := api.NewHandler(store)
+	echoServer := echo.New()
+	rateLimiter, err := ratelimit.Open(cfg, store)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create server: %w", err)
 	}
-	handler := api.NewHandler(store)
-	echoServer := echo.New()
-	echoServer.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(rate.Limit(reqPerSec))))
+	// the forwarding headers are only trusted if they were added by the trusted proxies
+	echoServer.IPExtractor = clientIP
+	echoServer.HTTPErrorHandler = errorHandler
+	echoServer.Use(routeMiddleware)
 	echoServer.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{corsOrigin},
+		// the allowed origins are read for each request so that they follow configuration reloads
+		AllowOriginFunc: func(origin string) (bool, error) {
+			return allowOrigin(config.Get().CorsOrigin, origin), nil
+		},
+		ExposeHeaders: ratelimit.Headers,
 	}))
-	api.RegisterHandlers(echoServer, handler)
+	echoServer.Use(identityMiddleware)
+	authenticator, err := newAuthenticator(cfg, store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+	echoServer.Use(authMiddleware(authenticator, rateLimiter))
+	echoServer.Use(writeMiddleware)
+	echoServer.Use(rateLimitMiddleware(rateLimiter))
+	validator, err := newValidator(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+	if validator != nil {
+		echoServer.Use(validationMiddleware(validator))
+	}
+	services := rpc.NewServices(store)
+	if cfg.GRPCGateway {
+		if err := registerGateway(echoServer, services); err != nil {
+			return nil, fmt.Errorf("failed to create server: %w", err)
+		}
+	} else {
+		api.RegisterHandlers(echoServer, handler)
+	}
+	echoServer.RouteNotFound(notFoundRoute, handler.AppDefault)
+	health := newHealth(store)
+	apiHandler, err := withDocs(echoServer, cfg.APIDocs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+	grpcServer, grpcHealth := newGRPCServer(services, authenticator, rateLimiter)
+	apiHandler = withMetrics(withHealth(apiHandler, health), cfg.MetricsAddr)
+	if cfg.GRPCAddr == "" {
+		apiHandler = withGRPC(apiHandler, grpcServer)
+	}
 	return &Server{
 		httpServer: http.Server{
-			Addr:           addr,
-			Handler:        echoServer,
+			Addr:           cfg.Addr,
+			Handler:        tracing.Middleware(logging.Middleware(apiHandler)),
 			ReadTimeout:    timeout,
 			WriteTimeout:   timeout,
 			MaxHeaderBytes: maxHeaderBytes,
 		},
+		echo:            echoServer,
+		metricsServer:   newMetricsServer(cfg.MetricsAddr),
+		health:          health,
+		shutdownDelay:   cfg.ShutdownDelay,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		rateLimiter:     rateLimiter,
+		grpcServer:      grpcServer,
+		grpcHealth:      grpcHealth,
+		grpcHTTPServer:  newGRPCHTTPServer(cfg.GRPCAddr, grpcServer),
 	}, nil
 }
 
 func (server *Server) Start(insecure bool) error {
-	log.Printf("http server listening on %s", server.httpServer.Addr)
+	startMetricsServer(server.metricsServer)
+	slog.Info("http server listening", "addr", server.httpServer.Addr)
+	if server.grpcHTTPServer == nil {
+		slog.Info("grpc server listening", "addr", server.httpServer.Addr)
+	}
 	var err error
 	if !insecure {
-		err = server.httpServer.ListenAndServeTLS(config.Get(config.CertKey), config.Get(config.PrivkeyKey))
+		cfg := config.Get()
+		var certReloader *CertReloader
+		if certReloader, err = NewCertReloader(cfg.Cert, cfg.Privkey); err != nil {
+			return err
+		}
+		defer certReloader.Close()
+		server.health.setCertReloader(certReloader)
+		server.httpServer.TLSConfig = &tls.Config{
+			MinVersion:     cfg.TLSVersion(),
+			CipherSuites:   cfg.TLSCipherSuiteIDs(),
+			GetCertificate: certReloader.GetCertificate,
+			ClientAuth:     cfg.ClientAuthType(),
+		}
+		if cfg.ClientAuthType() != tls.NoClientCert {
+			if server.httpServer.TLSConfig.ClientCAs, err = loadClientCAs(cfg.ClientCA); err != nil {
+				return err
+			}
+			slog.Info("client authentication", "mode", cfg.ClientAuth)
+		}
+		startGRPCServer(server.grpcHTTPServer, server.httpServer.TLSConfig)
+		// the certificate and private key are given by the TLS configuration
+		err = server.httpServer.ListenAndServeTLS("", "")
 	} else {
+		// without TLS there is no ALPN, so HTTP/2 is accepted with prior knowledge for gRPC clients
+		if server.grpcHTTPServer != nil {
+			server.grpcHTTPServer.Handler = h2c.NewHandler(server.grpcHTTPServer.Handler, &http2.Server{})
+		} else {
+			server.httpServer.Handler = h2c.NewHandler(server.httpServer.Handler, &http2.Server{})
+		}
+		startGRPCServer(server.grpcHTTPServer, nil)
 		err = server.httpServer.ListenAndServe()
 	}
 	if err == http.ErrServerClosed {
@@ -64,10 +164,37 @@ func (server *Server) Start(insecure bool) error {
 	return err
 }
 
+// Stop fails the readiness check, waits for the shutdown delay so that the server is taken out of service,
+// then stops accepting requests and waits up to the shutdown timeout for the in-flight requests to finish
 func (server *Server) Stop() error {
-	if err := server.httpServer.Shutdown(context.Background()); err != nil {
+	server.health.drain()
+	server.grpcHealth.Shutdown()
+	if server.shutdownDelay > 0 {
+		slog.Info("http server draining", "delay", server.shutdownDelay)
+		time.Sleep(server.shutdownDelay)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), server.shutdownTimeout)
+	defer cancel()
+	err := server.httpServer.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		// the requests that are still in flight are abandoned
+		server.httpServer.Close()
+		err = fmt.Errorf("%w: %s", ErrShutdownTimeout, server.shutdownTimeout)
+	}
+	if grpcErr := stopGRPCServer(ctx, server.grpcHTTPServer); grpcErr != nil {
+		server.grpcHTTPServer.Close()
+		err = errors.Join(err, fmt.Errorf("failed to stop grpc server: %w", grpcErr))
+	}
+	server.grpcServer.Stop()
+	if metricsErr := stopMetricsServer(server.metricsServer); metricsErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to stop metrics server: %w", metricsErr))
+	}
+	if rateLimiterErr := server.rateLimiter.Close(); rateLimiterErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close rate limiter: %w", rateLimiterErr))
+	}
+	if err != nil {
 		return err
 	}
-	log.Print("http server stopped")
+	slog.Info("http server stopped")
 	return nil
 }
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keith-cullen/microservice/api"
+	"github.com/keith-cullen/microservice/problem"
+)
+
+func TestThingsCRUD(t *testing.T) {
+	handler := newTestServer(t, nil)
+
+	w := serve(t, handler, http.MethodPost, "/v1/things", api.ThingInput{Name: "bob", Description: ptr("a thing"), Attributes: &map[string]any{"colour": "red"}})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: got %d: %s", w.Code, w.Body)
+	}
+	created := decode[api.Thing](t, w)
+	if created.Name != "bob" || created.Description != "a thing" || created.Attributes["colour"] != "red" {
+		t.Fatalf("create: got %+v", created)
+	}
+	if location := w.Header().Get("Location"); location != "/v1/things/"+created.Id.String() {
+		t.Errorf("create: got Location %q", location)
+	}
+	path := "/v1/things/" + created.Id.String()
+
+	w = serve(t, handler, http.MethodGet, path, nil)
+	if w.Code != http.StatusOK || decode[api.Thing](t, w).Id != created.Id {
+		t.Fatalf("get: got %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(t, handler, http.MethodPut, path, api.ThingInput{Name: "alice"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("replace: got %d: %s", w.Code, w.Body)
+	}
+	if replaced := decode[api.Thing](t, w); replaced.Name != "alice" || replaced.Description != "" || len(replaced.Attributes) != 0 {
+		t.Fatalf("replace: got %+v", replaced)
+	}
+
+	w = serve(t, handler, http.MethodPatch, path, api.ThingPatch{Description: ptr("patched")})
+	if w.Code != http.StatusOK {
+		t.Fatalf("update: got %d: %s", w.Code, w.Body)
+	}
+	if updated := decode[api.Thing](t, w); updated.Name != "alice" || updated.Description != "patched" {
+		t.Fatalf("update: got %+v", updated)
+	}
+
+	w = serve(t, handler, http.MethodGet, "/v1/things", nil)
+	if list := decode[api.ThingList](t, w); w.Code != http.StatusOK || len(list.Things) != 1 {
+		t.Fatalf("list: got %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(t, handler, http.MethodDelete, path, nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("delete: got %d: %s", w.Code, w.Body)
+	}
+	w = serve(t, handler, http.MethodGet, path, nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("get after delete: got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestThingsErrors(t *testing.T) {
+	handler := newTestServer(t, nil)
+	if w := serve(t, handler, http.MethodPost, "/v1/things", api.ThingInput{Name: "bob"}); w.Code != http.StatusCreated {
+		t.Fatalf("create: got %d: %s", w.Code, w.Body)
+	}
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		status int
+		field  string
+	}{
+		{"duplicate name", http.MethodPost, "/v1/things", api.ThingInput{Name: "bob"}, http.StatusConflict, ""},
+		{"empty name", http.MethodPost, "/v1/things", api.ThingInput{}, http.StatusBadRequest, "name"},
+		{"invalid id", http.MethodGet, "/v1/things/42", nil, http.StatusBadRequest, "id"},
+		{"unknown id", http.MethodGet, "/v1/things/6f1f1f3c-4d4e-4c1a-9a39-1b2b3c4d5e6f", nil, http.StatusNotFound, ""},
+		// the catch-all route answers the methods that are not served with not found
+		{"method not served", http.MethodPost, "/v1/things/6f1f1f3c-4d4e-4c1a-9a39-1b2b3c4d5e6f", nil, http.StatusNotFound, ""},
+		{"get without name", http.MethodGet, "/v1/get", nil, http.StatusBadRequest, "name"},
+		{"set without name", http.MethodPost, "/v1/set", nil, http.StatusBadRequest, "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, handler, tt.method, tt.target, tt.body)
+			if w.Code != tt.status {
+				t.Fatalf("got %d: %s", w.Code, w.Body)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != problem.ContentType {
+				t.Errorf("got Content-Type %q", contentType)
+			}
+			p := decode[problem.Problem](t, w)
+			if p.Status != tt.status {
+				t.Errorf("got problem status %d", p.Status)
+			}
+			if tt.field != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.field) {
+				t.Errorf("got field errors %+v", p.Errors)
+			}
+		})
+	}
+}
+
+func TestAppSetAndGet(t *testing.T) {
+	handler := newTestServer(t, nil)
+	for range 2 {
+		// setting a name again touches the existing thing
+		w := serve(t, handler, http.MethodPost, "/v1/set?name=bob", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("set: got %d: %s", w.Code, w.Body)
+		}
+		if w.Header().Get("Deprecation") != "true" {
+			t.Errorf("set: got no Deprecation header")
+		}
+	}
+	w := serve(t, handler, http.MethodGet, "/v1/get?name=bob", nil)
+	if resp := decode[api.AppResponse](t, w); w.Code != http.StatusOK || resp.Message != "Hello, bob" {
+		t.Fatalf("get: got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(t, handler, http.MethodGet, "/v1/get?name=alice", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("get unknown: got %d: %s", w.Code, w.Body)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
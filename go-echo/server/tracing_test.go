@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keith-cullen/microservice/tracing"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans makes the spans that end from now on until the end of a test available to it
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	if _, err := tracing.Setup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	previous := otel.GetTracerProvider()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestTracing(t *testing.T) {
+	handler := newTestServer(t, nil)
+	recorder := recordSpans(t)
+	const traceID, parentID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	r := httptest.NewRequest(http.MethodGet, "/v1/things", nil)
+	r.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	var server trace.SpanContext
+	var queries int
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != traceID {
+			t.Errorf("span %q has trace ID %s, want the incoming one", span.Name(), span.SpanContext().TraceID())
+		}
+		switch span.SpanKind() {
+		case trace.SpanKindServer:
+			server = span.SpanContext()
+			if span.Name() != "GET /v1/things" || span.Parent().SpanID().String() != parentID || !span.Parent().IsRemote() {
+				t.Errorf("got server span %q with parent %s", span.Name(), span.Parent().SpanID())
+			}
+		case trace.SpanKindClient:
+			queries++
+		}
+	}
+	if !server.IsValid() {
+		t.Fatal("got no server span")
+	}
+	if queries == 0 {
+		t.Fatal("got no store spans")
+	}
+	// the store spans are children of the server span
+	for _, span := range recorder.Ended() {
+		if span.SpanKind() == trace.SpanKindClient && span.Parent().SpanID() != server.SpanID() {
+			t.Errorf("store span %q has parent %s, want the server span %s", span.Name(), span.Parent().SpanID(), server.SpanID())
+		}
+	}
+}
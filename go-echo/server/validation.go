@@ -0,0 +1,44 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/keith-cullen/microservice/api"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/validation"
+	"github.com/labstack/echo/v4"
+)
+
+// newValidator creates the validator of the OpenAPI spec that is selected by the configuration, nil if validation is off
+func newValidator(cfg *config.Config) (*validation.Validator, error) {
+	if cfg.OpenAPIValidation == "off" {
+		return nil, nil
+	}
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	return validation.New(spec, cfg.OpenAPIValidation == "strict")
+}
+
+// validationMiddleware validates requests, and in strict mode responses, against the OpenAPI spec
+// Unlike echo.WrapMiddleware it sends the errors of the handlers within the middleware so that their responses are validated too
+func validationMiddleware(validator *validation.Validator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			writer := ctx.Response().Writer
+			defer func() {
+				ctx.Response().Writer = writer
+			}()
+			validator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx.SetRequest(r)
+				ctx.Response().Writer = w
+				if err := next(ctx); err != nil {
+					ctx.Error(err)
+				}
+			})).ServeHTTP(writer, ctx.Request())
+			return nil
+		}
+	}
+}
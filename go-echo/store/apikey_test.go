@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/store/ent"
+)
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		expired := time.Now().Add(-time.Minute)
+		later := time.Now().Add(time.Hour)
+		valid, validSecret, err := store.CreateAPIKey(ctx, APIKeyFields{Owner: "alice", Scopes: []string{"things:read"}, ExpiresAt: &later})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, expiredSecret, err := store.CreateAPIKey(ctx, APIKeyFields{Owner: "bob", ExpiresAt: &expired})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests := []struct {
+			name   string
+			secret string
+			valid  bool
+		}{
+			{"valid", validSecret, true},
+			{"expired", expiredSecret, false},
+			{"unknown", apiKeyPrefix + "unknown", false},
+			{"no prefix", validSecret[len(apiKeyPrefix):], false},
+		}
+		for _, tt := range tests {
+			k, err := store.AuthenticateAPIKey(ctx, tt.secret)
+			if !tt.valid {
+				if !errors.Is(err, ErrInvalidAPIKey) {
+					t.Errorf("%s: got error %v, want %v", tt.name, err, ErrInvalidAPIKey)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if k.ID != valid.ID || k.Owner != "alice" {
+				t.Errorf("%s: got key %v", tt.name, k)
+			}
+			if k.LastUsedAt == nil {
+				t.Errorf("%s: got no last used time", tt.name)
+			}
+		}
+		stored, err := store.Client.APIKey.Get(ctx, valid.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.LastUsedAt == nil {
+			t.Error("the last used time was not stored")
+		}
+	})
+}
+
+func TestAuthenticateAPIKeyCache(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		k, secret, err := store.CreateAPIKey(ctx, APIKeyFields{Owner: "alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.AuthenticateAPIKey(ctx, secret); err != nil {
+			t.Fatal(err)
+		}
+		// a change that another replica makes in the database is not seen until the cached lookup expires
+		if err := store.Client.APIKey.UpdateOneID(k.ID).SetExpiresAt(time.Now().Add(-time.Minute)).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.AuthenticateAPIKey(ctx, secret); err != nil {
+			t.Fatalf("cached: %v", err)
+		}
+		store.apiKeys.mu.Lock()
+		for hash, entry := range store.apiKeys.entries {
+			entry.fetchedAt = entry.fetchedAt.Add(-apiKeyCacheTTL - time.Second)
+			store.apiKeys.entries[hash] = entry
+		}
+		store.apiKeys.mu.Unlock()
+		if _, err := store.AuthenticateAPIKey(ctx, secret); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Fatalf("after the cached lookup expired: got error %v, want %v", err, ErrInvalidAPIKey)
+		}
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		k, secret, err := store.CreateAPIKey(ctx, APIKeyFields{Owner: "alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, otherSecret, err := store.CreateAPIKey(ctx, APIKeyFields{Owner: "bob"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []string{secret, otherSecret} {
+			if _, err := store.AuthenticateAPIKey(ctx, s); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// revoking a key takes effect immediately in this process, even though its lookup was cached
+		for i := 0; i < 2; i++ {
+			if err := store.RevokeAPIKey(ctx, k.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.AuthenticateAPIKey(ctx, secret); !errors.Is(err, ErrInvalidAPIKey) {
+				t.Fatalf("got error %v, want %v", err, ErrInvalidAPIKey)
+			}
+		}
+		if got, err := store.AuthenticateAPIKey(ctx, otherSecret); err != nil || got.ID != other.ID {
+			t.Fatalf("other key: got %v: %v", got, err)
+		}
+	})
+}
+
+func TestAPIKeyCacheRevokedDuringLookup(t *testing.T) {
+	var cache apiKeyCache
+	k := &ent.APIKey{ID: uuid.New()}
+	// the lookup reads the key from the database before it is revoked and caches it after
+	revocations := cache.revocations()
+	cache.remove(k.ID)
+	cache.put("hash", k, revocations)
+	if _, ok := cache.get("hash"); ok {
+		t.Error("a lookup that raced with a revocation was cached")
+	}
+	cache.put("hash", k, cache.revocations())
+	if entry, ok := cache.get("hash"); !ok || entry.key != k {
+		t.Error("a lookup was not cached")
+	}
+}
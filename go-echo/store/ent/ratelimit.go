@@ -0,0 +1,103 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
+)
+
+// RateLimit is the model entity for the RateLimit schema.
+type RateLimit struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// Tat holds the value of the "tat" field.
+	Tat          int64 `json:"tat,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*RateLimit) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case ratelimit.FieldTat:
+			values[i] = new(sql.NullInt64)
+		case ratelimit.FieldID:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the RateLimit fields.
+func (rl *RateLimit) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case ratelimit.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				rl.ID = value.String
+			}
+		case ratelimit.FieldTat:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field tat", values[i])
+			} else if value.Valid {
+				rl.Tat = value.Int64
+			}
+		default:
+			rl.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the RateLimit.
+// This includes values selected through modifiers, order, etc.
+func (rl *RateLimit) Value(name string) (ent.Value, error) {
+	return rl.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this RateLimit.
+// Note that you need to call RateLimit.Unwrap() before calling this method if this RateLimit
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (rl *RateLimit) Update() *RateLimitUpdateOne {
+	return NewRateLimitClient(rl.config).UpdateOne(rl)
+}
+
+// Unwrap unwraps the RateLimit entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (rl *RateLimit) Unwrap() *RateLimit {
+	_tx, ok := rl.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: RateLimit is not a transactional entity")
+	}
+	rl.config.driver = _tx.drv
+	return rl
+}
+
+// String implements the fmt.Stringer.
+func (rl *RateLimit) String() string {
+	var builder strings.Builder
+	builder.WriteString("RateLimit(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", rl.ID))
+	builder.WriteString("tat=")
+	builder.WriteString(fmt.Sprintf("%v", rl.Tat))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// RateLimits is a parsable slice of RateLimit.
+type RateLimits []*RateLimit
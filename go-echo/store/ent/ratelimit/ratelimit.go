@@ -0,0 +1,52 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ratelimit
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the ratelimit type in the database.
+	Label = "rate_limit"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTat holds the string denoting the tat field in the database.
+	FieldTat = "tat"
+	// Table holds the table name of the ratelimit in the database.
+	Table = "rate_limits"
+)
+
+// Columns holds all SQL columns for ratelimit fields.
+var Columns = []string{
+	FieldID,
+	FieldTat,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the RateLimit queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTat orders the results by the tat field.
+func ByTat(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTat, opts...).ToFunc()
+}
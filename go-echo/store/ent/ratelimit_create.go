@@ -0,0 +1,514 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
+)
+
+// RateLimitCreate is the builder for creating a RateLimit entity.
+type RateLimitCreate struct {
+	config
+	mutation *RateLimitMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetTat sets the "tat" field.
+func (rlc *RateLimitCreate) SetTat(i int64) *RateLimitCreate {
+	rlc.mutation.SetTat(i)
+	return rlc
+}
+
+// SetID sets the "id" field.
+func (rlc *RateLimitCreate) SetID(s string) *RateLimitCreate {
+	rlc.mutation.SetID(s)
+	return rlc
+}
+
+// Mutation returns the RateLimitMutation object of the builder.
+func (rlc *RateLimitCreate) Mutation() *RateLimitMutation {
+	return rlc.mutation
+}
+
+// Save creates the RateLimit in the database.
+func (rlc *RateLimitCreate) Save(ctx context.Context) (*RateLimit, error) {
+	return withHooks(ctx, rlc.sqlSave, rlc.mutation, rlc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rlc *RateLimitCreate) SaveX(ctx context.Context) *RateLimit {
+	v, err := rlc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rlc *RateLimitCreate) Exec(ctx context.Context) error {
+	_, err := rlc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rlc *RateLimitCreate) ExecX(ctx context.Context) {
+	if err := rlc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rlc *RateLimitCreate) check() error {
+	if _, ok := rlc.mutation.Tat(); !ok {
+		return &ValidationError{Name: "tat", err: errors.New(`ent: missing required field "RateLimit.tat"`)}
+	}
+	if v, ok := rlc.mutation.ID(); ok {
+		if err := ratelimit.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "RateLimit.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (rlc *RateLimitCreate) sqlSave(ctx context.Context) (*RateLimit, error) {
+	if err := rlc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := rlc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rlc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected RateLimit.ID type: %T", _spec.ID.Value)
+		}
+	}
+	rlc.mutation.id = &_node.ID
+	rlc.mutation.done = true
+	return _node, nil
+}
+
+func (rlc *RateLimitCreate) createSpec() (*RateLimit, *sqlgraph.CreateSpec) {
+	var (
+		_node = &RateLimit{config: rlc.config}
+		_spec = sqlgraph.NewCreateSpec(ratelimit.Table, sqlgraph.NewFieldSpec(ratelimit.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = rlc.conflict
+	if id, ok := rlc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := rlc.mutation.Tat(); ok {
+		_spec.SetField(ratelimit.FieldTat, field.TypeInt64, value)
+		_node.Tat = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.RateLimit.Create().
+//		SetTat(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.RateLimitUpsert) {
+//			SetTat(v+v).
+//		}).
+//		Exec(ctx)
+func (rlc *RateLimitCreate) OnConflict(opts ...sql.ConflictOption) *RateLimitUpsertOne {
+	rlc.conflict = opts
+	return &RateLimitUpsertOne{
+		create: rlc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.RateLimit.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (rlc *RateLimitCreate) OnConflictColumns(columns ...string) *RateLimitUpsertOne {
+	rlc.conflict = append(rlc.conflict, sql.ConflictColumns(columns...))
+	return &RateLimitUpsertOne{
+		create: rlc,
+	}
+}
+
+type (
+	// RateLimitUpsertOne is the builder for "upsert"-ing
+	//  one RateLimit node.
+	RateLimitUpsertOne struct {
+		create *RateLimitCreate
+	}
+
+	// RateLimitUpsert is the "OnConflict" setter.
+	RateLimitUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetTat sets the "tat" field.
+func (u *RateLimitUpsert) SetTat(v int64) *RateLimitUpsert {
+	u.Set(ratelimit.FieldTat, v)
+	return u
+}
+
+// UpdateTat sets the "tat" field to the value that was provided on create.
+func (u *RateLimitUpsert) UpdateTat() *RateLimitUpsert {
+	u.SetExcluded(ratelimit.FieldTat)
+	return u
+}
+
+// AddTat adds v to the "tat" field.
+func (u *RateLimitUpsert) AddTat(v int64) *RateLimitUpsert {
+	u.Add(ratelimit.FieldTat, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.RateLimit.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(ratelimit.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *RateLimitUpsertOne) UpdateNewValues() *RateLimitUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(ratelimit.FieldID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.RateLimit.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *RateLimitUpsertOne) Ignore() *RateLimitUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *RateLimitUpsertOne) DoNothing() *RateLimitUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the RateLimitCreate.OnConflict
+// documentation for more info.
+func (u *RateLimitUpsertOne) Update(set func(*RateLimitUpsert)) *RateLimitUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&RateLimitUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetTat sets the "tat" field.
+func (u *RateLimitUpsertOne) SetTat(v int64) *RateLimitUpsertOne {
+	return u.Update(func(s *RateLimitUpsert) {
+		s.SetTat(v)
+	})
+}
+
+// AddTat adds v to the "tat" field.
+func (u *RateLimitUpsertOne) AddTat(v int64) *RateLimitUpsertOne {
+	return u.Update(func(s *RateLimitUpsert) {
+		s.AddTat(v)
+	})
+}
+
+// UpdateTat sets the "tat" field to the value that was provided on create.
+func (u *RateLimitUpsertOne) UpdateTat() *RateLimitUpsertOne {
+	return u.Update(func(s *RateLimitUpsert) {
+		s.UpdateTat()
+	})
+}
+
+// Exec executes the query.
+func (u *RateLimitUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for RateLimitCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *RateLimitUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *RateLimitUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: RateLimitUpsertOne.ID is not supported by MySQL driver. Use RateLimitUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *RateLimitUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// RateLimitCreateBulk is the builder for creating many RateLimit entities in bulk.
+type RateLimitCreateBulk struct {
+	config
+	err      error
+	builders []*RateLimitCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the RateLimit entities in the database.
+func (rlcb *RateLimitCreateBulk) Save(ctx context.Context) ([]*RateLimit, error) {
+	if rlcb.err != nil {
+		return nil, rlcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(rlcb.builders))
+	nodes := make([]*RateLimit, len(rlcb.builders))
+	mutators := make([]Mutator, len(rlcb.builders))
+	for i := range rlcb.builders {
+		func(i int, root context.Context) {
+			builder := rlcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RateLimitMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rlcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = rlcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rlcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rlcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rlcb *RateLimitCreateBulk) SaveX(ctx context.Context) []*RateLimit {
+	v, err := rlcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rlcb *RateLimitCreateBulk) Exec(ctx context.Context) error {
+	_, err := rlcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rlcb *RateLimitCreateBulk) ExecX(ctx context.Context) {
+	if err := rlcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.RateLimit.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.RateLimitUpsert) {
+//			SetTat(v+v).
+//		}).
+//		Exec(ctx)
+func (rlcb *RateLimitCreateBulk) OnConflict(opts ...sql.ConflictOption) *RateLimitUpsertBulk {
+	rlcb.conflict = opts
+	return &RateLimitUpsertBulk{
+		create: rlcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.RateLimit.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (rlcb *RateLimitCreateBulk) OnConflictColumns(columns ...string) *RateLimitUpsertBulk {
+	rlcb.conflict = append(rlcb.conflict, sql.ConflictColumns(columns...))
+	return &RateLimitUpsertBulk{
+		create: rlcb,
+	}
+}
+
+// RateLimitUpsertBulk is the builder for "upsert"-ing
+// a bulk of RateLimit nodes.
+type RateLimitUpsertBulk struct {
+	create *RateLimitCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.RateLimit.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(ratelimit.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *RateLimitUpsertBulk) UpdateNewValues() *RateLimitUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(ratelimit.FieldID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.RateLimit.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *RateLimitUpsertBulk) Ignore() *RateLimitUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *RateLimitUpsertBulk) DoNothing() *RateLimitUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the RateLimitCreateBulk.OnConflict
+// documentation for more info.
+func (u *RateLimitUpsertBulk) Update(set func(*RateLimitUpsert)) *RateLimitUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&RateLimitUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetTat sets the "tat" field.
+func (u *RateLimitUpsertBulk) SetTat(v int64) *RateLimitUpsertBulk {
+	return u.Update(func(s *RateLimitUpsert) {
+		s.SetTat(v)
+	})
+}
+
+// AddTat adds v to the "tat" field.
+func (u *RateLimitUpsertBulk) AddTat(v int64) *RateLimitUpsertBulk {
+	return u.Update(func(s *RateLimitUpsert) {
+		s.AddTat(v)
+	})
+}
+
+// UpdateTat sets the "tat" field to the value that was provided on create.
+func (u *RateLimitUpsertBulk) UpdateTat() *RateLimitUpsertBulk {
+	return u.Update(func(s *RateLimitUpsert) {
+		s.UpdateTat()
+	})
+}
+
+// Exec executes the query.
+func (u *RateLimitUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the RateLimitCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for RateLimitCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *RateLimitUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
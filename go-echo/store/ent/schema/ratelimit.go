@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// RateLimit holds the schema definition for the RateLimit entity.
+// The ID is the rate limit key, e.g. the client IP address.
+type RateLimit struct {
+	ent.Schema
+}
+
+// Fields of the RateLimit.
+func (RateLimit) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			MaxLen(255).
+			NotEmpty().
+			Immutable(),
+		// tat is the theoretical arrival time of the next request in microseconds since the Unix epoch,
+		// it is an integer so that every database keeps its full precision
+		field.Int64("tat"),
+	}
+}
+
+// Edges of the RateLimit.
+func (RateLimit) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the RateLimit.
+func (RateLimit) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tat"),
+	}
+}
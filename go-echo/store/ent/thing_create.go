@@ -6,9 +6,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
 	"github.com/keith-cullen/microservice/store/ent/thing"
 )
 
@@ -17,6 +21,35 @@ type ThingCreate struct {
 	config
 	mutation *ThingMutation
 	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (tc *ThingCreate) SetCreatedAt(t time.Time) *ThingCreate {
+	tc.mutation.SetCreatedAt(t)
+	return tc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (tc *ThingCreate) SetNillableCreatedAt(t *time.Time) *ThingCreate {
+	if t != nil {
+		tc.SetCreatedAt(*t)
+	}
+	return tc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tc *ThingCreate) SetUpdatedAt(t time.Time) *ThingCreate {
+	tc.mutation.SetUpdatedAt(t)
+	return tc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (tc *ThingCreate) SetNillableUpdatedAt(t *time.Time) *ThingCreate {
+	if t != nil {
+		tc.SetUpdatedAt(*t)
+	}
+	return tc
 }
 
 // SetName sets the "name" field.
@@ -33,6 +66,40 @@ func (tc *ThingCreate) SetNillableName(s *string) *ThingCreate {
 	return tc
 }
 
+// SetDescription sets the "description" field.
+func (tc *ThingCreate) SetDescription(s string) *ThingCreate {
+	tc.mutation.SetDescription(s)
+	return tc
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tc *ThingCreate) SetNillableDescription(s *string) *ThingCreate {
+	if s != nil {
+		tc.SetDescription(*s)
+	}
+	return tc
+}
+
+// SetAttributes sets the "attributes" field.
+func (tc *ThingCreate) SetAttributes(m map[string]interface{}) *ThingCreate {
+	tc.mutation.SetAttributes(m)
+	return tc
+}
+
+// SetID sets the "id" field.
+func (tc *ThingCreate) SetID(u uuid.UUID) *ThingCreate {
+	tc.mutation.SetID(u)
+	return tc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (tc *ThingCreate) SetNillableID(u *uuid.UUID) *ThingCreate {
+	if u != nil {
+		tc.SetID(*u)
+	}
+	return tc
+}
+
 // Mutation returns the ThingMutation object of the builder.
 func (tc *ThingCreate) Mutation() *ThingMutation {
 	return tc.mutation
@@ -68,17 +135,42 @@ func (tc *ThingCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (tc *ThingCreate) defaults() {
+	if _, ok := tc.mutation.CreatedAt(); !ok {
+		v := thing.DefaultCreatedAt()
+		tc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := tc.mutation.UpdatedAt(); !ok {
+		v := thing.DefaultUpdatedAt()
+		tc.mutation.SetUpdatedAt(v)
+	}
 	if _, ok := tc.mutation.Name(); !ok {
 		v := thing.DefaultName
 		tc.mutation.SetName(v)
 	}
+	if _, ok := tc.mutation.Description(); !ok {
+		v := thing.DefaultDescription
+		tc.mutation.SetDescription(v)
+	}
+	if _, ok := tc.mutation.ID(); !ok {
+		v := thing.DefaultID()
+		tc.mutation.SetID(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
 func (tc *ThingCreate) check() error {
+	if _, ok := tc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Thing.created_at"`)}
+	}
+	if _, ok := tc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Thing.updated_at"`)}
+	}
 	if _, ok := tc.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Thing.name"`)}
 	}
+	if _, ok := tc.mutation.Description(); !ok {
+		return &ValidationError{Name: "description", err: errors.New(`ent: missing required field "Thing.description"`)}
+	}
 	return nil
 }
 
@@ -93,8 +185,13 @@ func (tc *ThingCreate) sqlSave(ctx context.Context) (*Thing, error) {
 		}
 		return nil, err
 	}
-	id := _spec.ID.Value.(int64)
-	_node.ID = int(id)
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
 	tc.mutation.id = &_node.ID
 	tc.mutation.done = true
 	return _node, nil
@@ -103,20 +200,297 @@ func (tc *ThingCreate) sqlSave(ctx context.Context) (*Thing, error) {
 func (tc *ThingCreate) createSpec() (*Thing, *sqlgraph.CreateSpec) {
 	var (
 		_node = &Thing{config: tc.config}
-		_spec = sqlgraph.NewCreateSpec(thing.Table, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeInt))
+		_spec = sqlgraph.NewCreateSpec(thing.Table, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeUUID))
 	)
+	_spec.OnConflict = tc.conflict
+	if id, ok := tc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := tc.mutation.CreatedAt(); ok {
+		_spec.SetField(thing.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := tc.mutation.UpdatedAt(); ok {
+		_spec.SetField(thing.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
 	if value, ok := tc.mutation.Name(); ok {
 		_spec.SetField(thing.FieldName, field.TypeString, value)
 		_node.Name = value
 	}
+	if value, ok := tc.mutation.Description(); ok {
+		_spec.SetField(thing.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := tc.mutation.Attributes(); ok {
+		_spec.SetField(thing.FieldAttributes, field.TypeJSON, value)
+		_node.Attributes = value
+	}
 	return _node, _spec
 }
 
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Thing.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ThingUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (tc *ThingCreate) OnConflict(opts ...sql.ConflictOption) *ThingUpsertOne {
+	tc.conflict = opts
+	return &ThingUpsertOne{
+		create: tc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Thing.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (tc *ThingCreate) OnConflictColumns(columns ...string) *ThingUpsertOne {
+	tc.conflict = append(tc.conflict, sql.ConflictColumns(columns...))
+	return &ThingUpsertOne{
+		create: tc,
+	}
+}
+
+type (
+	// ThingUpsertOne is the builder for "upsert"-ing
+	//  one Thing node.
+	ThingUpsertOne struct {
+		create *ThingCreate
+	}
+
+	// ThingUpsert is the "OnConflict" setter.
+	ThingUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *ThingUpsert) SetUpdatedAt(v time.Time) *ThingUpsert {
+	u.Set(thing.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *ThingUpsert) UpdateUpdatedAt() *ThingUpsert {
+	u.SetExcluded(thing.FieldUpdatedAt)
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *ThingUpsert) SetName(v string) *ThingUpsert {
+	u.Set(thing.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *ThingUpsert) UpdateName() *ThingUpsert {
+	u.SetExcluded(thing.FieldName)
+	return u
+}
+
+// SetDescription sets the "description" field.
+func (u *ThingUpsert) SetDescription(v string) *ThingUpsert {
+	u.Set(thing.FieldDescription, v)
+	return u
+}
+
+// UpdateDescription sets the "description" field to the value that was provided on create.
+func (u *ThingUpsert) UpdateDescription() *ThingUpsert {
+	u.SetExcluded(thing.FieldDescription)
+	return u
+}
+
+// SetAttributes sets the "attributes" field.
+func (u *ThingUpsert) SetAttributes(v map[string]interface{}) *ThingUpsert {
+	u.Set(thing.FieldAttributes, v)
+	return u
+}
+
+// UpdateAttributes sets the "attributes" field to the value that was provided on create.
+func (u *ThingUpsert) UpdateAttributes() *ThingUpsert {
+	u.SetExcluded(thing.FieldAttributes)
+	return u
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (u *ThingUpsert) ClearAttributes() *ThingUpsert {
+	u.SetNull(thing.FieldAttributes)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.Thing.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(thing.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ThingUpsertOne) UpdateNewValues() *ThingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(thing.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(thing.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Thing.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *ThingUpsertOne) Ignore() *ThingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ThingUpsertOne) DoNothing() *ThingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ThingCreate.OnConflict
+// documentation for more info.
+func (u *ThingUpsertOne) Update(set func(*ThingUpsert)) *ThingUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ThingUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *ThingUpsertOne) SetUpdatedAt(v time.Time) *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *ThingUpsertOne) UpdateUpdatedAt() *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *ThingUpsertOne) SetName(v string) *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *ThingUpsertOne) UpdateName() *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetDescription sets the "description" field.
+func (u *ThingUpsertOne) SetDescription(v string) *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetDescription(v)
+	})
+}
+
+// UpdateDescription sets the "description" field to the value that was provided on create.
+func (u *ThingUpsertOne) UpdateDescription() *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateDescription()
+	})
+}
+
+// SetAttributes sets the "attributes" field.
+func (u *ThingUpsertOne) SetAttributes(v map[string]interface{}) *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetAttributes(v)
+	})
+}
+
+// UpdateAttributes sets the "attributes" field to the value that was provided on create.
+func (u *ThingUpsertOne) UpdateAttributes() *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateAttributes()
+	})
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (u *ThingUpsertOne) ClearAttributes() *ThingUpsertOne {
+	return u.Update(func(s *ThingUpsert) {
+		s.ClearAttributes()
+	})
+}
+
+// Exec executes the query.
+func (u *ThingUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ThingCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ThingUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *ThingUpsertOne) ID(ctx context.Context) (id uuid.UUID, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: ThingUpsertOne.ID is not supported by MySQL driver. Use ThingUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *ThingUpsertOne) IDX(ctx context.Context) uuid.UUID {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
 // ThingCreateBulk is the builder for creating many Thing entities in bulk.
 type ThingCreateBulk struct {
 	config
 	err      error
 	builders []*ThingCreate
+	conflict []sql.ConflictOption
 }
 
 // Save creates the Thing entities in the database.
@@ -146,6 +520,7 @@ func (tcb *ThingCreateBulk) Save(ctx context.Context) ([]*Thing, error) {
 					_, err = mutators[i+1].Mutate(root, tcb.builders[i+1].mutation)
 				} else {
 					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = tcb.conflict
 					// Invoke the actual operation on the latest mutation in the chain.
 					if err = sqlgraph.BatchCreate(ctx, tcb.driver, spec); err != nil {
 						if sqlgraph.IsConstraintError(err) {
@@ -157,10 +532,6 @@ func (tcb *ThingCreateBulk) Save(ctx context.Context) ([]*Thing, error) {
 					return nil, err
 				}
 				mutation.id = &nodes[i].ID
-				if specs[i].ID.Value != nil {
-					id := specs[i].ID.Value.(int64)
-					nodes[i].ID = int(id)
-				}
 				mutation.done = true
 				return nodes[i], nil
 			})
@@ -199,3 +570,183 @@ func (tcb *ThingCreateBulk) ExecX(ctx context.Context) {
 		panic(err)
 	}
 }
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Thing.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ThingUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (tcb *ThingCreateBulk) OnConflict(opts ...sql.ConflictOption) *ThingUpsertBulk {
+	tcb.conflict = opts
+	return &ThingUpsertBulk{
+		create: tcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Thing.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (tcb *ThingCreateBulk) OnConflictColumns(columns ...string) *ThingUpsertBulk {
+	tcb.conflict = append(tcb.conflict, sql.ConflictColumns(columns...))
+	return &ThingUpsertBulk{
+		create: tcb,
+	}
+}
+
+// ThingUpsertBulk is the builder for "upsert"-ing
+// a bulk of Thing nodes.
+type ThingUpsertBulk struct {
+	create *ThingCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Thing.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(thing.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ThingUpsertBulk) UpdateNewValues() *ThingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(thing.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(thing.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Thing.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *ThingUpsertBulk) Ignore() *ThingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ThingUpsertBulk) DoNothing() *ThingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ThingCreateBulk.OnConflict
+// documentation for more info.
+func (u *ThingUpsertBulk) Update(set func(*ThingUpsert)) *ThingUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ThingUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *ThingUpsertBulk) SetUpdatedAt(v time.Time) *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *ThingUpsertBulk) UpdateUpdatedAt() *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetName sets the "name" field.
+func (u *ThingUpsertBulk) SetName(v string) *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *ThingUpsertBulk) UpdateName() *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateName()
+	})
+}
+
+// SetDescription sets the "description" field.
+func (u *ThingUpsertBulk) SetDescription(v string) *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetDescription(v)
+	})
+}
+
+// UpdateDescription sets the "description" field to the value that was provided on create.
+func (u *ThingUpsertBulk) UpdateDescription() *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateDescription()
+	})
+}
+
+// SetAttributes sets the "attributes" field.
+func (u *ThingUpsertBulk) SetAttributes(v map[string]interface{}) *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.SetAttributes(v)
+	})
+}
+
+// UpdateAttributes sets the "attributes" field to the value that was provided on create.
+func (u *ThingUpsertBulk) UpdateAttributes() *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.UpdateAttributes()
+	})
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (u *ThingUpsertBulk) ClearAttributes() *ThingUpsertBulk {
+	return u.Update(func(s *ThingUpsert) {
+		s.ClearAttributes()
+	})
+}
+
+// Exec executes the query.
+func (u *ThingUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the ThingCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ThingCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ThingUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
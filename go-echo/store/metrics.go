@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	entgo "entgo.io/ent"
+	"github.com/keith-cullen/microservice/metrics"
+	"github.com/keith-cullen/microservice/store/ent"
+)
+
+// instrument records the latency and errors of every query and mutation made by a client
+// The operation is named after the entity and the ent operation, e.g. Thing.Create or APIKey.Only
+// A query that finds nothing is not counted as an error
+func instrument(client *ent.Client) {
+	client.Intercept(ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			start := time.Now()
+			v, err := next.Query(ctx, q)
+			operation := "query"
+			if qc := entgo.QueryFromContext(ctx); qc != nil {
+				operation = fmt.Sprintf("%s.%s", qc.Type, qc.Op)
+			}
+			metrics.ObserveStore(operation, time.Since(start), err != nil && !ent.IsNotFound(err))
+			return v, err
+		})
+	}))
+	client.Use(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			start := time.Now()
+			v, err := next.Mutate(ctx, m)
+			operation := fmt.Sprintf("%s.%s", m.Type(), strings.TrimPrefix(m.Op().String(), "Op"))
+			metrics.ObserveStore(operation, time.Since(start), err != nil && !ent.IsNotFound(err))
+			return v, err
+		})
+	})
+}
@@ -0,0 +1,328 @@
+// Package migrate applies the versioned schema migrations that are checked into this directory
+//
+// The migrations are generated from the ent schema by main.go in golang-migrate format
+// Each migration has a version, an up script and a down script
+// The versions of the migrations that have been applied are recorded in the schema_revisions table
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+const (
+	revisionsTable = "schema_revisions"
+	upSuffix       = ".up.sql"
+	downSuffix     = ".down.sql"
+)
+
+var (
+	ErrBehind = errors.New("database schema is behind the binary")
+	ErrAhead  = errors.New("database schema is ahead of the binary")
+)
+
+//go:embed sqlite/*.sql postgres/*.sql mysql/*.sql
+var files embed.FS
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes the state of the database schema relative to the migrations known to the binary
+type Status struct {
+	Current string      // Current is the latest applied version, empty if no migration has been applied
+	Latest  string      // Latest is the latest version known to the binary
+	Pending []Migration // Pending migrations are known to the binary but have not been applied
+	Unknown []string    // Unknown versions have been applied but are not known to the binary
+}
+
+// dirs maps ent dialect names to migration directories
+var dirs = map[string]string{
+	dialect.SQLite:   "sqlite",
+	dialect.Postgres: "postgres",
+	dialect.MySQL:    "mysql",
+}
+
+// Migrations returns the migrations for a dialect in version order
+func Migrations(dialectName string) ([]Migration, error) {
+	dir, ok := dirs[dialectName]
+	if !ok {
+		return nil, fmt.Errorf("no migrations for dialect: %s", dialectName)
+	}
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	byVersion := map[string]*Migration{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var base string
+		switch {
+		case strings.HasSuffix(fileName, upSuffix):
+			base = strings.TrimSuffix(fileName, upSuffix)
+		case strings.HasSuffix(fileName, downSuffix):
+			base = strings.TrimSuffix(fileName, downSuffix)
+		default:
+			continue
+		}
+		version, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name: %s", fileName)
+		}
+		content, err := fs.ReadFile(files, path.Join(dir, fileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration: %w", err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if strings.HasSuffix(fileName, upSuffix) {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// TableExists reports whether a table exists in the database
+func TableExists(ctx context.Context, db *sql.DB, dialectName, table string) (bool, error) {
+	var query string
+	switch dialectName {
+	case dialect.SQLite:
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	case dialect.Postgres:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+	case dialect.MySQL:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	default:
+		return false, fmt.Errorf("unsupported dialect: %s", dialectName)
+	}
+	var count int
+	if err := db.QueryRowContext(ctx, query, table).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to find table: %s: %w", table, err)
+	}
+	return count > 0, nil
+}
+
+// placeholder returns the nth (1-based) query argument placeholder for a dialect
+func placeholder(dialectName string, n int) string {
+	if dialectName == dialect.Postgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// applied returns the applied versions in version order
+func applied(ctx context.Context, db *sql.DB, dialectName string) ([]string, error) {
+	exists, err := TableExists(ctx, db, dialectName, revisionsTable)
+	if err != nil || !exists {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT version FROM %s ORDER BY version", revisionsTable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema revisions: %w", err)
+	}
+	defer rows.Close()
+	var versions []string
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to read schema revisions: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// Initialized reports whether any migration has ever been applied to the database
+func Initialized(ctx context.Context, db *sql.DB, dialectName string) (bool, error) {
+	return TableExists(ctx, db, dialectName, revisionsTable)
+}
+
+// GetStatus compares the applied versions with the migrations known to the binary
+func GetStatus(ctx context.Context, db *sql.DB, dialectName string) (*Status, error) {
+	migrations, err := Migrations(dialectName)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := applied(ctx, db, dialectName)
+	if err != nil {
+		return nil, err
+	}
+	status := &Status{}
+	if len(migrations) > 0 {
+		status.Latest = migrations[len(migrations)-1].Version
+	}
+	if len(versions) > 0 {
+		status.Current = versions[len(versions)-1]
+	}
+	done := map[string]bool{}
+	for _, version := range versions {
+		done[version] = true
+	}
+	known := map[string]bool{}
+	for _, m := range migrations {
+		known[m.Version] = true
+		if !done[m.Version] {
+			status.Pending = append(status.Pending, m)
+		}
+	}
+	for _, version := range versions {
+		if !known[version] {
+			status.Unknown = append(status.Unknown, version)
+		}
+	}
+	return status, nil
+}
+
+// Check returns an error if the database schema does not match the migrations known to the binary
+func Check(ctx context.Context, db *sql.DB, dialectName string) error {
+	status, err := GetStatus(ctx, db, dialectName)
+	if err != nil {
+		return err
+	}
+	current := status.Current
+	if current == "" {
+		current = "none"
+	}
+	if len(status.Unknown) > 0 {
+		return fmt.Errorf("%w: database version: %s, binary version: %s", ErrAhead, current, status.Latest)
+	}
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("%w: database version: %s, binary version: %s", ErrBehind, current, status.Latest)
+	}
+	return nil
+}
+
+// Up applies all pending migrations in version order and returns them
+// Each migration is applied in its own transaction
+func Up(ctx context.Context, db *sql.DB, dialectName string) ([]Migration, error) {
+	status, err := GetStatus(ctx, db, dialectName)
+	if err != nil {
+		return nil, err
+	}
+	if len(status.Unknown) > 0 {
+		return nil, fmt.Errorf("%w: unknown versions: %s", ErrAhead, strings.Join(status.Unknown, ", "))
+	}
+	for i, m := range status.Pending {
+		if err := apply(ctx, db, dialectName, m, m.Up, true); err != nil {
+			return status.Pending[:i], err
+		}
+	}
+	return status.Pending, nil
+}
+
+// Down reverts the latest applied migration and returns it
+// It returns nil if no migration has been applied
+func Down(ctx context.Context, db *sql.DB, dialectName string) (*Migration, error) {
+	status, err := GetStatus(ctx, db, dialectName)
+	if err != nil {
+		return nil, err
+	}
+	if status.Current == "" {
+		return nil, nil
+	}
+	if len(status.Unknown) > 0 {
+		return nil, fmt.Errorf("%w: unknown versions: %s", ErrAhead, strings.Join(status.Unknown, ", "))
+	}
+	migrations, err := Migrations(dialectName)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range migrations {
+		if m.Version == status.Current {
+			if err := apply(ctx, db, dialectName, m, m.Down, false); err != nil {
+				return nil, err
+			}
+			return &m, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to find migration: %s", status.Current)
+}
+
+// Baseline records a migration as applied without running it
+// This is used for databases whose schema was created before versioned migrations were introduced
+func Baseline(ctx context.Context, db *sql.DB, dialectName string, m Migration) error {
+	return apply(ctx, db, dialectName, m, "", true)
+}
+
+// apply runs a migration script and records or removes its version in a single transaction
+func apply(ctx context.Context, db *sql.DB, dialectName string, m Migration, script string, up bool) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (version VARCHAR(32) NOT NULL PRIMARY KEY, applied_at TIMESTAMP NOT NULL)",
+		revisionsTable)); err != nil {
+		return fmt.Errorf("failed to create schema revisions table: %w", err)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to apply migration: %s: %w", m.Version, err)
+	}
+	for _, stmt := range statements(script) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration: %s: %w", m.Version, err)
+		}
+	}
+	if up {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version, applied_at) VALUES (%s, %s)",
+			revisionsTable, placeholder(dialectName, 1), placeholder(dialectName, 2)), m.Version, time.Now().UTC())
+	} else {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE version = %s",
+			revisionsTable, placeholder(dialectName, 1)), m.Version)
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record migration: %s: %w", m.Version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to apply migration: %s: %w", m.Version, err)
+	}
+	return nil
+}
+
+// statements splits a migration script into statements
+// Comment lines are dropped and statements are terminated by a semicolon at the end of a line
+func statements(script string) []string {
+	var stmts []string
+	var stmt strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		stmt.WriteString(line)
+		stmt.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSpace(stmt.String()))
+			stmt.Reset()
+		}
+	}
+	if s := strings.TrimSpace(stmt.String()); s != "" {
+		stmts = append(stmts, s)
+	}
+	return stmts
+}
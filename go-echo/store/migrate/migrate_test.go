@@ -0,0 +1,76 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openSQLite returns a new in-memory SQLite database
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file::memory:?_fk=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection to ":memory:" opens a different database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUpDownSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	migrations, err := Migrations(dialect.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("got no migrations")
+	}
+	if err := Check(ctx, db, dialect.SQLite); !errors.Is(err, ErrBehind) {
+		t.Fatalf("got error %v, want %v", err, ErrBehind)
+	}
+	// applying and reverting every migration twice leaves the same schema
+	for round := 0; round < 2; round++ {
+		applied, err := Up(ctx, db, dialect.SQLite)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != len(migrations) {
+			t.Fatalf("round %d: applied %d of %d migrations", round, len(applied), len(migrations))
+		}
+		applied, err = Up(ctx, db, dialect.SQLite)
+		if err != nil || len(applied) != 0 {
+			t.Fatalf("round %d: up again applied %d migrations: %v", round, len(applied), err)
+		}
+		if err := Check(ctx, db, dialect.SQLite); err != nil {
+			t.Fatal(err)
+		}
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m, err := Down(ctx, db, dialect.SQLite)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m == nil || m.Version != migrations[i].Version {
+				t.Fatalf("round %d: reverted %v, want %s", round, m, migrations[i].Version)
+			}
+		}
+		m, err := Down(ctx, db, dialect.SQLite)
+		if err != nil || m != nil {
+			t.Fatalf("round %d: down again reverted %v: %v", round, m, err)
+		}
+		exists, err := TableExists(ctx, db, dialect.SQLite, "things")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists {
+			t.Fatalf("round %d: things table exists after reverting every migration", round)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+-- create "things" table
+CREATE TABLE `things` (`id` char(36) NOT NULL, `created_at` timestamp NOT NULL, `updated_at` timestamp NOT NULL, `name` varchar(255) NOT NULL DEFAULT "unknown", `description` varchar(255) NOT NULL DEFAULT "", `attributes` json NULL, PRIMARY KEY (`id`), UNIQUE INDEX `thing_name` (`name`)) CHARSET utf8mb4 COLLATE utf8mb4_bin;
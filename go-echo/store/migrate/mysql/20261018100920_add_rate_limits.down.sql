@@ -0,0 +1,2 @@
+-- reverse: create "rate_limits" table
+DROP TABLE `rate_limits`;
@@ -0,0 +1,2 @@
+-- create "rate_limits" table
+CREATE TABLE `rate_limits` (`id` varchar(255) NOT NULL, `tat` bigint NOT NULL, PRIMARY KEY (`id`), INDEX `ratelimit_tat` (`tat`)) CHARSET utf8mb4 COLLATE utf8mb4_bin;
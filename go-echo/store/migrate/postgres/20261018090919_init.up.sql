@@ -0,0 +1,4 @@
+-- create "things" table
+CREATE TABLE "things" ("id" uuid NOT NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "name" character varying NOT NULL DEFAULT 'unknown', "description" character varying NOT NULL DEFAULT '', "attributes" jsonb NULL, PRIMARY KEY ("id"));
+-- create index "thing_name" to table: "things"
+CREATE UNIQUE INDEX "thing_name" ON "things" ("name");
@@ -0,0 +1,4 @@
+-- reverse: create index "apikey_hash" to table: "api_keys"
+DROP INDEX "apikey_hash";
+-- reverse: create "api_keys" table
+DROP TABLE "api_keys";
@@ -0,0 +1,4 @@
+-- create "api_keys" table
+CREATE TABLE "api_keys" ("id" uuid NOT NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "hash" character varying NOT NULL, "owner" character varying NOT NULL, "scopes" jsonb NOT NULL, "expires_at" timestamptz NULL, "last_used_at" timestamptz NULL, "revoked_at" timestamptz NULL, PRIMARY KEY ("id"));
+-- create index "apikey_hash" to table: "api_keys"
+CREATE UNIQUE INDEX "apikey_hash" ON "api_keys" ("hash");
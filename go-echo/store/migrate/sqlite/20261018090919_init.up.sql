@@ -0,0 +1,4 @@
+-- create "things" table
+CREATE TABLE `things` (`id` uuid NOT NULL, `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `name` text NOT NULL DEFAULT ('unknown'), `description` text NOT NULL DEFAULT (''), `attributes` json NULL, PRIMARY KEY (`id`));
+-- create index "thing_name" to table: "things"
+CREATE UNIQUE INDEX `thing_name` ON `things` (`name`);
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
+)
+
+// GetRateLimit returns the theoretical arrival time of the next request for a rate limit key
+// in microseconds since the Unix epoch, found is false if the key has none
+func (store *Store) GetRateLimit(ctx context.Context, key string) (tat int64, found bool, err error) {
+	r, err := store.Client.RateLimit.Get(ctx, key)
+	if ent.IsNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get rate limit: %w", err)
+	}
+	return r.Tat, true, nil
+}
+
+// SwapRateLimit sets the theoretical arrival time of a rate limit key to tat if it is still old, or creates it if found is false
+// It returns false if the rate limit was changed by another request since old was read
+func (store *Store) SwapRateLimit(ctx context.Context, key string, old int64, found bool, tat int64) (bool, error) {
+	if !found {
+		err := store.Client.RateLimit.Create().
+			SetID(key).
+			SetTat(tat).
+			Exec(ctx)
+		if ent.IsConstraintError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to create rate limit: %w", err)
+		}
+		return true, nil
+	}
+	n, err := store.Client.RateLimit.Update().
+		Where(ratelimit.ID(key), ratelimit.Tat(old)).
+		SetTat(tat).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to update rate limit: %w", err)
+	}
+	return n == 1, nil
+}
+
+// DeleteRateLimits deletes the rate limit keys whose theoretical arrival time is before tat,
+// their next request would be allowed with a full burst anyway
+func (store *Store) DeleteRateLimits(ctx context.Context, tat int64) (int, error) {
+	n, err := store.Client.RateLimit.Delete().
+		Where(ratelimit.TatLT(tat)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rate limits: %w", err)
+	}
+	return n, nil
+}
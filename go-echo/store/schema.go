@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/keith-cullen/microservice/store/ent/thing"
+	"github.com/keith-cullen/microservice/store/migrate"
+)
+
+// checkSchema returns an error if the database schema version does not match the binary
+// The schema of an empty database is created, so that a new deployment does not have to run the 'migrate up' command first
+func checkSchema(ctx context.Context, db *sql.DB, dialectName string) error {
+	empty, err := emptyDatabase(ctx, db, dialectName)
+	if err != nil {
+		return err
+	}
+	if empty {
+		applied, err := migrate.Up(ctx, db, dialectName)
+		for _, m := range applied {
+			slog.Info("applied migration to the empty database", "version", m.Version, "name", m.Name)
+		}
+		return err
+	}
+	err = migrate.Check(ctx, db, dialectName)
+	if errors.Is(err, migrate.ErrBehind) {
+		return fmt.Errorf("%w: run the 'migrate up' command", err)
+	}
+	return err
+}
+
+// emptyDatabase reports whether no migration has ever been applied to a database and it has no things table
+// Databases whose migrations have been reverted, or whose schema was created before versioned migrations, are not empty
+func emptyDatabase(ctx context.Context, db *sql.DB, dialectName string) (bool, error) {
+	initialized, err := migrate.Initialized(ctx, db, dialectName)
+	if err != nil || initialized {
+		return false, err
+	}
+	exists, err := migrate.TableExists(ctx, db, dialectName, thing.Table)
+	return !exists, err
+}
+
+// MigrateUp applies all pending schema migrations
+// Databases created before versioned migrations were introduced are adopted first
+func MigrateUp(ctx context.Context, w io.Writer) error {
+	db, dialectName, err := openDB()
+	if err != nil {
+		return err
+	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialectName, db)))
+	defer client.Close()
+	initialized, err := migrate.Initialized(ctx, db, dialectName)
+	if err != nil {
+		return err
+	}
+	if !initialized {
+		if err := adoptSchema(ctx, db, dialectName, w); err != nil {
+			return err
+		}
+	}
+	applied, err := migrate.Up(ctx, db, dialectName)
+	for _, m := range applied {
+		fmt.Fprintf(w, "applied migration %s %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Fprintln(w, "no pending migrations")
+	}
+	return copyLegacyThings(ctx, db, dialectName, client)
+}
+
+// adoptSchema prepares a database whose schema was not created by versioned migrations
+// Things tables with integer IDs are renamed so that their rows can be copied after the migrations are applied
+// Things tables that were created by auto-migration already match the first migration, which is recorded as applied
+func adoptSchema(ctx context.Context, db *sql.DB, dialectName string, w io.Writer) error {
+	if dialectName == dialect.SQLite {
+		if err := renameLegacyThings(ctx, db); err != nil {
+			return err
+		}
+	}
+	exists, err := migrate.TableExists(ctx, db, dialectName, thing.Table)
+	if err != nil || !exists {
+		return err
+	}
+	migrations, err := migrate.Migrations(dialectName)
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		return nil
+	}
+	if err := migrate.Baseline(ctx, db, dialectName, migrations[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "recorded existing schema as migration %s %s\n", migrations[0].Version, migrations[0].Name)
+	return nil
+}
+
+// MigrateDown reverts the latest applied schema migration
+func MigrateDown(ctx context.Context, w io.Writer) error {
+	db, dialectName, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	m, err := migrate.Down(ctx, db, dialectName)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		fmt.Fprintln(w, "no applied migrations")
+		return nil
+	}
+	fmt.Fprintf(w, "reverted migration %s %s\n", m.Version, m.Name)
+	return nil
+}
+
+// MigrateStatus reports the schema version of the database and the pending migrations
+func MigrateStatus(ctx context.Context, w io.Writer) error {
+	db, dialectName, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	status, err := migrate.GetStatus(ctx, db, dialectName)
+	if err != nil {
+		return err
+	}
+	current := status.Current
+	if current == "" {
+		current = "none"
+	}
+	fmt.Fprintf(w, "database version: %s\n", current)
+	fmt.Fprintf(w, "binary version: %s\n", status.Latest)
+	for _, m := range status.Pending {
+		fmt.Fprintf(w, "pending: %s %s\n", m.Version, m.Name)
+	}
+	for _, version := range status.Unknown {
+		fmt.Fprintf(w, "unknown: %s\n", version)
+	}
+	return nil
+}
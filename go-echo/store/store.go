@@ -2,107 +2,323 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"sync"
+	"log/slog"
+	"strings"
+	"time"
 
-	"github.com/keith-cullen/microservice/config"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/metrics"
 	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/keith-cullen/microservice/store/ent/predicate"
 	"github.com/keith-cullen/microservice/store/ent/thing"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
-const (
-	DatabaseDriverName = "sqlite3"
+var (
+	ErrThingExists        = errors.New("thing already exists")
+	ErrInvalidListOptions = errors.New("invalid list options")
 )
 
 type Store struct {
-	mu     sync.Mutex
-	Client *ent.Client
+	Client  *ent.Client
+	db      *sql.DB
+	apiKeys apiKeyCache
 }
 
 func Open() (*Store, error) {
-	client, err := ent.Open(DatabaseDriverName, config.Get(config.DatabaseFileKey))
+	db, dialectName, err := openDB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open store: %w", err)
 	}
-	ctx := context.Background()
-	if err := client.Schema.Create(ctx); err != nil {
+	if err := checkSchema(context.Background(), db, dialectName); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to open store: %w", err)
 	}
-	log.Print("store open")
+	client := ent.NewClient(ent.Driver(tracedDriver{entsql.OpenDB(dialectName, db)}))
+	instrument(client)
+	metrics.RegisterDB(db)
+	slog.Info("store open")
 	return &Store{
 		Client: client,
+		db:     db,
 	}, nil
 }
 
+// Ping checks that the database is reachable
+func (store *Store) Ping(ctx context.Context) error {
+	if err := store.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
 func (store *Store) Close() {
 	store.Client.Close()
-	log.Print("store closed")
+	slog.Info("store closed")
 }
 
 func (store *Store) GetThing(ctx context.Context, name string) (string, error) {
-	store.mu.Lock()
-	defer store.mu.Unlock()
 	_, err := store.Client.Thing.
 		Query().
 		Where(thing.Name(name)).
 		Only(ctx)
 	if err != nil {
 		err = fmt.Errorf("failed to get thing: %w", err)
-		log.Print(err)
+		slog.WarnContext(ctx, err.Error())
 		return "", err
 	}
-	log.Printf("got thing: %q", name)
+	slog.DebugContext(ctx, "got thing", "name", name)
 	return name, nil
 }
 
+// SetThing creates a thing with the given name or touches the existing one in a single atomic upsert
+// The unique name index makes this safe when several replicas share one database
 func (store *Store) SetThing(ctx context.Context, name string) error {
-	store.mu.Lock()
-	defer store.mu.Unlock()
-	t, err := store.Client.Thing.
-		Query().
-		Where(thing.Name(name)).
-		Only(ctx)
-	switch err.(type) {
-	case *ent.NotSingularError:
+	err := store.Client.Thing.
+		Create().
+		SetName(name).
+		OnConflictColumns(thing.FieldName).
+		UpdateUpdatedAt().
+		Exec(ctx)
+	if err != nil {
 		err = fmt.Errorf("failed to set thing: %w", err)
-		log.Print(err)
-	case *ent.NotFoundError:
-		err = store.createThing(ctx, name)
-	default:
-		err = store.updateThing(ctx, t)
+		slog.ErrorContext(ctx, err.Error())
+		return err
 	}
-	return err
+	slog.InfoContext(ctx, "set thing", "name", name)
+	return nil
 }
 
-// unsafe - store.mu must be locked when this method is called
-func (store *Store) createThing(ctx context.Context, name string) error {
-	_, err := store.Client.Thing.
+// ThingFields holds the fields of a thing that can be set by clients
+type ThingFields struct {
+	Name        string
+	Description string
+	Attributes  map[string]any
+}
+
+// CreateThing creates a new thing with a unique name
+func (store *Store) CreateThing(ctx context.Context, fields ThingFields) (*ent.Thing, error) {
+	t, err := store.Client.Thing.
 		Create().
-		SetName(name).
+		SetName(fields.Name).
+		SetDescription(fields.Description).
+		SetAttributes(fields.Attributes).
 		Save(ctx)
 	if err != nil {
-		err = fmt.Errorf("failed to create thing: %w", err)
-		log.Print(err)
-		return err
+		err = fmt.Errorf("failed to create thing: %w", thingError(err))
+		slog.WarnContext(ctx, err.Error())
+		return nil, err
 	}
-	log.Printf("created thing: %q", name)
-	return nil
+	slog.InfoContext(ctx, "created thing", "id", t.ID, "name", t.Name)
+	return t, nil
 }
 
-// unsafe - store.mu must be locked when this method is called
-func (store *Store) updateThing(ctx context.Context, t *ent.Thing) error {
-	_, err := store.Client.Thing.
-		UpdateOne(t).
-		SetName(t.Name).
-		Save(ctx)
+// GetThingByID returns the thing with the given ID
+func (store *Store) GetThingByID(ctx context.Context, id uuid.UUID) (*ent.Thing, error) {
+	t, err := store.Client.Thing.Get(ctx, id)
+	if err != nil {
+		err = fmt.Errorf("failed to get thing: %w", err)
+		slog.WarnContext(ctx, err.Error())
+		return nil, err
+	}
+	slog.DebugContext(ctx, "got thing", "id", id)
+	return t, nil
+}
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 1000
+)
+
+// ListOptions filters, sorts and paginates the result of ListThings
+type ListOptions struct {
+	Prefix   string // Prefix only lists things whose name starts with this prefix
+	Contains string // Contains only lists things whose name contains this substring
+	Sort     string // Sort is one of "created_at", "name" or "id", a leading '-' sorts in descending order
+	Limit    int    // Limit is the maximum number of things in the page, zero means DefaultListLimit
+	Cursor   string // Cursor is the NextCursor of the previous page, empty for the first page
+}
+
+// ThingPage is one page of the result of ListThings
+type ThingPage struct {
+	Things     []*ent.Thing
+	NextCursor string // NextCursor is empty on the last page
+}
+
+// listCursor records the position of the last thing in a page
+// It is encoded as opaque base64 JSON so that clients do not depend on its contents
+type listCursor struct {
+	Sort      string    `json:"s"`
+	Name      string    `json:"n,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+}
+
+func encodeListCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(s string) (listCursor, error) {
+	var c listCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+// ListThings returns a page of things
+// Pagination is keyset based so that deep pages do not require offset scans
+func (store *Store) ListThings(ctx context.Context, opts ListOptions) (*ThingPage, error) {
+	if opts.Sort == "" {
+		opts.Sort = thing.FieldCreatedAt
+	}
+	desc := strings.HasPrefix(opts.Sort, "-")
+	field := strings.TrimPrefix(opts.Sort, "-")
+	if field != thing.FieldCreatedAt && field != thing.FieldName && field != thing.FieldID {
+		return nil, fmt.Errorf("failed to list things: unknown sort: %q: %w", opts.Sort, ErrInvalidListOptions)
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultListLimit
+	}
+	if opts.Limit < 0 || opts.Limit > MaxListLimit {
+		return nil, fmt.Errorf("failed to list things: limit out of range: %d: %w", opts.Limit, ErrInvalidListOptions)
+	}
+	query := store.Client.Thing.Query()
+	if opts.Prefix != "" {
+		query.Where(thing.NameHasPrefix(opts.Prefix))
+	}
+	if opts.Contains != "" {
+		query.Where(thing.NameContains(opts.Contains))
+	}
+	if opts.Cursor != "" {
+		c, err := decodeListCursor(opts.Cursor)
+		if err != nil || c.Sort != opts.Sort {
+			return nil, fmt.Errorf("failed to list things: invalid cursor: %w", ErrInvalidListOptions)
+		}
+		query.Where(afterCursor(field, desc, c))
+	}
+	order := ent.Asc
+	if desc {
+		order = ent.Desc
+	}
+	if field == thing.FieldID {
+		query.Order(order(thing.FieldID))
+	} else {
+		query.Order(order(field, thing.FieldID))
+	}
+	// fetch one extra thing to find out if there is a next page
+	things, err := query.Limit(opts.Limit + 1).All(ctx)
 	if err != nil {
-		err = fmt.Errorf("failed to update thing: %w", err)
-		log.Print(err)
+		err = fmt.Errorf("failed to list things: %w", err)
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	page := &ThingPage{Things: things}
+	if len(things) > opts.Limit {
+		page.Things = things[:opts.Limit]
+		last := page.Things[len(page.Things)-1]
+		c := listCursor{Sort: opts.Sort, ID: last.ID}
+		switch field {
+		case thing.FieldName:
+			c.Name = last.Name
+		case thing.FieldCreatedAt:
+			c.CreatedAt = last.CreatedAt
+		}
+		page.NextCursor = encodeListCursor(c)
+	}
+	slog.DebugContext(ctx, "listed things", "count", len(page.Things))
+	return page, nil
+}
+
+// afterCursor returns a predicate that selects the things that sort after the cursor position
+func afterCursor(field string, desc bool, c listCursor) predicate.Thing {
+	idAfter, nameAfter, createdAtAfter := thing.IDGT, thing.NameGT, thing.CreatedAtGT
+	if desc {
+		idAfter, nameAfter, createdAtAfter = thing.IDLT, thing.NameLT, thing.CreatedAtLT
+	}
+	switch field {
+	case thing.FieldName:
+		return thing.Or(
+			nameAfter(c.Name),
+			thing.And(thing.Name(c.Name), idAfter(c.ID)),
+		)
+	case thing.FieldCreatedAt:
+		return thing.Or(
+			createdAtAfter(c.CreatedAt),
+			thing.And(thing.CreatedAt(c.CreatedAt), idAfter(c.ID)),
+		)
+	default:
+		return idAfter(c.ID)
+	}
+}
+
+// ReplaceThing replaces all of the fields of the thing with the given ID
+func (store *Store) ReplaceThing(ctx context.Context, id uuid.UUID, fields ThingFields) (*ent.Thing, error) {
+	attributes := fields.Attributes
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return store.PatchThing(ctx, id, ThingPatch{
+		Name:        &fields.Name,
+		Description: &fields.Description,
+		Attributes:  attributes,
+	})
+}
+
+// ThingPatch holds the fields to be changed by PatchThing
+// A nil field is left unchanged, an empty Attributes map clears the attributes
+type ThingPatch struct {
+	Name        *string
+	Description *string
+	Attributes  map[string]any
+}
+
+// PatchThing changes the fields of the thing with the given ID that are set in the patch
+func (store *Store) PatchThing(ctx context.Context, id uuid.UUID, patch ThingPatch) (*ent.Thing, error) {
+	update := store.Client.Thing.
+		UpdateOneID(id).
+		SetNillableName(patch.Name).
+		SetNillableDescription(patch.Description)
+	if patch.Attributes != nil {
+		if len(patch.Attributes) == 0 {
+			update.ClearAttributes()
+		} else {
+			update.SetAttributes(patch.Attributes)
+		}
+	}
+	t, err := update.Save(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to update thing: %w", thingError(err))
+		slog.WarnContext(ctx, err.Error())
+		return nil, err
+	}
+	slog.InfoContext(ctx, "updated thing", "id", id)
+	return t, nil
+}
+
+// DeleteThing deletes the thing with the given ID
+func (store *Store) DeleteThing(ctx context.Context, id uuid.UUID) error {
+	if err := store.Client.Thing.DeleteOneID(id).Exec(ctx); err != nil {
+		err = fmt.Errorf("failed to delete thing: %w", err)
+		slog.WarnContext(ctx, err.Error())
 		return err
 	}
-	log.Printf("updated thing: %q", t.Name)
+	slog.InfoContext(ctx, "deleted thing", "id", id)
 	return nil
 }
+
+// thingError converts a violation of the unique name index into ErrThingExists
+func thingError(err error) error {
+	if ent.IsConstraintError(err) {
+		return fmt.Errorf("%w: %w", ErrThingExists, err)
+	}
+	return err
+}
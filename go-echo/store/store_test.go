@@ -0,0 +1,419 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/keith-cullen/microservice/store/ent/thing"
+	"github.com/keith-cullen/microservice/store/migrate"
+)
+
+// postgresDSNEnv names the environment variable with the data source name of a Postgres database for the tests
+// The tests that run against every backend are skipped on Postgres unless it is set, they drop the schema of the database
+const postgresDSNEnv = "MICROSERVICE_TEST_POSTGRES_DSN"
+
+// testBackend is a database that the store tests run against
+type testBackend struct {
+	name   string
+	driver string
+	dsn    func(t testing.TB) string // dsn returns the data source name of the database or skips the test
+}
+
+var testBackends = []testBackend{
+	{name: "sqlite", driver: "sqlite3", dsn: sqliteDSN},
+	{name: "postgres", driver: "postgres", dsn: postgresDSN},
+}
+
+// concurrentBackends are the backends of the tests with concurrent writers
+// SQLite is a file with the write-ahead log, since concurrent writers to a shared in-memory database fail with locking errors
+var concurrentBackends = []testBackend{
+	{name: "sqlite", driver: "sqlite3", dsn: sqliteFileDSN},
+	{name: "postgres", driver: "postgres", dsn: postgresDSN},
+}
+
+// sqliteDSN returns a new in-memory SQLite database that lives until the end of the test
+func sqliteDSN(t testing.TB) string {
+	dsn := "file:" + strings.ReplaceAll(t.Name(), "/", "_") + "?mode=memory&cache=shared&_fk=1"
+	// the in-memory database only lives while one of its connections is open
+	keep, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keep.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { keep.Close() })
+	return dsn
+}
+
+// sqliteFileDSN returns a new SQLite database file that is removed at the end of the test
+func sqliteFileDSN(t testing.TB) string {
+	return "file:" + filepath.Join(t.TempDir(), "store.db") + "?_fk=1"
+}
+
+// postgresDSN returns the Postgres database given in the environment or skips the test
+func postgresDSN(t testing.TB) string {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+	return dsn
+}
+
+// openTestStore returns a store on an empty database of a backend with the schema applied
+func openTestStore(t testing.TB, backend testBackend) *Store {
+	t.Helper()
+	ctx := context.Background()
+	t.Setenv(config.EnvName("DatabaseDriver"), backend.driver)
+	t.Setenv(config.EnvName("DatabaseDSN"), backend.dsn(t))
+	if err := config.Open(""); err != nil {
+		t.Fatal(err)
+	}
+	// a database that is shared between test runs is emptied by reverting its migrations
+	for {
+		var out strings.Builder
+		if err := MigrateDown(ctx, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() == "no applied migrations\n" {
+			break
+		}
+	}
+	if err := MigrateUp(ctx, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	store, err := Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(store.Close)
+	return store
+}
+
+// forEachBackend runs a test against a store on each of the test backends
+func forEachBackend(t *testing.T, test func(t *testing.T, store *Store)) {
+	forBackends(t, testBackends, test)
+}
+
+// forBackends runs a test against a store on each backend
+func forBackends(t *testing.T, backends []testBackend, test func(t *testing.T, store *Store)) {
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, openTestStore(t, backend))
+		})
+	}
+}
+
+func TestThings(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		created, err := store.CreateThing(ctx, ThingFields{Name: "bob", Description: "a thing", Attributes: map[string]any{"colour": "red"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		description := "patched"
+		tests := []struct {
+			name  string
+			run   func() (*ent.Thing, error)
+			want  ThingFields
+			error error
+			// notFound expects the error of a missing thing
+			notFound bool
+		}{
+			{
+				name: "get",
+				run:  func() (*ent.Thing, error) { return store.GetThingByID(ctx, created.ID) },
+				want: ThingFields{Name: "bob", Description: "a thing", Attributes: map[string]any{"colour": "red"}},
+			},
+			{
+				name:  "create with a name that exists",
+				run:   func() (*ent.Thing, error) { return store.CreateThing(ctx, ThingFields{Name: "bob"}) },
+				error: ErrThingExists,
+			},
+			{
+				name: "patch",
+				run: func() (*ent.Thing, error) {
+					return store.PatchThing(ctx, created.ID, ThingPatch{Description: &description})
+				},
+				want: ThingFields{Name: "bob", Description: "patched", Attributes: map[string]any{"colour": "red"}},
+			},
+			{
+				name: "clear attributes",
+				run: func() (*ent.Thing, error) {
+					return store.PatchThing(ctx, created.ID, ThingPatch{Attributes: map[string]any{}})
+				},
+				want: ThingFields{Name: "bob", Description: "patched"},
+			},
+			{
+				name: "replace",
+				run: func() (*ent.Thing, error) {
+					return store.ReplaceThing(ctx, created.ID, ThingFields{Name: "alice", Attributes: map[string]any{"size": "large"}})
+				},
+				want: ThingFields{Name: "alice", Attributes: map[string]any{"size": "large"}},
+			},
+			{
+				name: "replace with a name that exists",
+				run: func() (*ent.Thing, error) {
+					other, err := store.CreateThing(ctx, ThingFields{Name: "carol"})
+					if err != nil {
+						return nil, err
+					}
+					return store.ReplaceThing(ctx, other.ID, ThingFields{Name: "alice"})
+				},
+				error: ErrThingExists,
+			},
+			{
+				name:     "get unknown",
+				run:      func() (*ent.Thing, error) { return store.GetThingByID(ctx, uuid.New()) },
+				notFound: true,
+			},
+		}
+		for _, tt := range tests {
+			thing, err := tt.run()
+			if tt.notFound {
+				if !ent.IsNotFound(err) {
+					t.Errorf("%s: got error %v, want not found", tt.name, err)
+				}
+				continue
+			}
+			if tt.error != nil {
+				if !errors.Is(err, tt.error) {
+					t.Errorf("%s: got error %v, want %v", tt.name, err, tt.error)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			got := ThingFields{Name: thing.Name, Description: thing.Description, Attributes: thing.Attributes}
+			if len(got.Attributes) == 0 {
+				got.Attributes = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+			}
+		}
+		if err := store.DeleteThing(ctx, created.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.DeleteThing(ctx, created.ID); !ent.IsNotFound(err) {
+			t.Errorf("delete again: got error %v", err)
+		}
+	})
+}
+
+func TestSetThing(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		if _, err := store.GetThing(ctx, "bob"); !ent.IsNotFound(err) {
+			t.Fatalf("got error %v", err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := store.SetThing(ctx, "bob"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		name, err := store.GetThing(ctx, "bob")
+		if err != nil || name != "bob" {
+			t.Fatalf("got %q: %v", name, err)
+		}
+	})
+}
+
+func TestSetThingConcurrently(t *testing.T) {
+	forBackends(t, concurrentBackends, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		const writers = 16
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- store.SetThing(ctx, "bob")
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		count, err := store.Client.Thing.Query().Where(thing.Name("bob")).Count(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Errorf("got %d things named bob", count)
+		}
+	})
+}
+
+func BenchmarkSetThingParallel(b *testing.B) {
+	for _, backend := range concurrentBackends {
+		b.Run(backend.name, func(b *testing.B) {
+			store := openTestStore(b, backend)
+			ctx := context.Background()
+			var n atomic.Int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					// a few names so that the writers both insert and update
+					if err := store.SetThing(ctx, fmt.Sprintf("thing%d", n.Add(1)%8)); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestMigrateUpAgain(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		if _, err := store.CreateThing(ctx, ThingFields{Name: "bob"}); err != nil {
+			t.Fatal(err)
+		}
+		var out strings.Builder
+		if err := MigrateUp(ctx, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != "no pending migrations\n" {
+			t.Errorf("got output %q", out.String())
+		}
+		if _, err := store.GetThing(ctx, "bob"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestOpenEmptyDatabase(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(config.EnvName("DatabaseDSN"), sqliteDSN(t))
+	if err := config.Open(""); err != nil {
+		t.Fatal(err)
+	}
+	store, err := Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateThing(ctx, ThingFields{Name: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+	// a database whose migrations have been reverted is behind rather than empty
+	for {
+		var out strings.Builder
+		if err := MigrateDown(ctx, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() == "no applied migrations\n" {
+			break
+		}
+	}
+	if _, err := Open(); !errors.Is(err, migrate.ErrBehind) {
+		t.Errorf("got error %v, want %v", err, migrate.ErrBehind)
+	}
+}
+
+func TestListCursorRoundTrip(t *testing.T) {
+	c := listCursor{Sort: "-created_at", Name: "bob", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)}
+	decoded, err := decodeListCursor(encodeListCursor(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != c {
+		t.Errorf("got %+v, want %+v", decoded, c)
+	}
+}
+
+func TestListThingsPages(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		const count = 7
+		for i := 0; i < count; i++ {
+			if _, err := store.CreateThing(ctx, ThingFields{Name: fmt.Sprintf("thing%d", count-1-i)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, sort := range []string{"created_at", "-created_at", "name", "-name", "id", "-id"} {
+			t.Run(sort, func(t *testing.T) {
+				all, err := store.ListThings(ctx, ListOptions{Sort: sort, Limit: count})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if all.NextCursor != "" || len(all.Things) != count {
+					t.Fatalf("got %d things and cursor %q", len(all.Things), all.NextCursor)
+				}
+				// following the cursors lists the same things in the same order
+				var names []string
+				opts := ListOptions{Sort: sort, Limit: 3}
+				for {
+					page, err := store.ListThings(ctx, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, thing := range page.Things {
+						names = append(names, thing.Name)
+					}
+					if page.NextCursor == "" {
+						break
+					}
+					opts.Cursor = page.NextCursor
+				}
+				for i, thing := range all.Things {
+					if i >= len(names) || names[i] != thing.Name {
+						t.Fatalf("got %v, want the order of %v", names, all.Things)
+					}
+				}
+				if len(names) != count {
+					t.Fatalf("got %v", names)
+				}
+			})
+		}
+	})
+}
+
+func TestListThingsCursorWithOtherSort(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, store *Store) {
+		ctx := context.Background()
+		for _, name := range []string{"a", "b", "c"} {
+			if _, err := store.CreateThing(ctx, ThingFields{Name: name}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		page, err := store.ListThings(ctx, ListOptions{Sort: "name", Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.NextCursor == "" {
+			t.Fatal("got no cursor")
+		}
+		for _, opts := range []ListOptions{
+			{Sort: "-name", Cursor: page.NextCursor},
+			{Sort: "created_at", Cursor: page.NextCursor},
+			{Cursor: page.NextCursor},
+			{Sort: "name", Cursor: "not a cursor"},
+		} {
+			if _, err := store.ListThings(ctx, opts); !errors.Is(err, ErrInvalidListOptions) {
+				t.Errorf("%+v: got error %v, want %v", opts, err, ErrInvalidListOptions)
+			}
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"github.com/keith-cullen/microservice/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbSystemNames maps ent dialects to the OpenTelemetry database system names
+var dbSystemNames = map[string]attribute.KeyValue{
+	dialect.SQLite:   semconv.DBSystemNameSQLite,
+	dialect.Postgres: semconv.DBSystemNamePostgreSQL,
+	dialect.MySQL:    semconv.DBSystemNameMySQL,
+}
+
+// tracedDriver is an ent driver that wraps each query in a client span
+type tracedDriver struct {
+	dialect.Driver
+}
+
+func (d tracedDriver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuerySpan(ctx, d.Dialect(), query)
+	defer span.End()
+	err := d.Driver.Exec(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (d tracedDriver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuerySpan(ctx, d.Dialect(), query)
+	defer span.End()
+	err := d.Driver.Query(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (d tracedDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tracedTx{Tx: tx, dialect: d.Dialect()}, nil
+}
+
+// BeginTx is used by ent.Client.BeginTx
+func (d tracedDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return d.Tx(ctx)
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return tracedTx{Tx: tx, dialect: d.Dialect()}, nil
+}
+
+// tracedTx is an ent transaction that wraps each query in a client span
+type tracedTx struct {
+	dialect.Tx
+	dialect string
+}
+
+func (tx tracedTx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuerySpan(ctx, tx.dialect, query)
+	defer span.End()
+	err := tx.Tx.Exec(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (tx tracedTx) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuerySpan(ctx, tx.dialect, query)
+	defer span.End()
+	err := tx.Tx.Query(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// startQuerySpan starts a span named after the SQL operation of a query
+// The query text only contains placeholders, the arguments are not recorded
+func startQuerySpan(ctx context.Context, dialectName, query string) (context.Context, trace.Span) {
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	operation = strings.ToUpper(operation)
+	return tracing.Tracer().Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			dbSystemNames[dialectName],
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(query),
+		),
+	)
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/keith-cullen/microservice/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName         = "microservice"
+	instrumentationName = "github.com/keith-cullen/microservice"
+)
+
+// Tracer returns the tracer that creates the spans of the application
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the W3C trace context propagator and the span exporter selected by TraceExporter
+// It returns a function that flushes the spans that have not been exported yet and stops the exporter
+// The trace context of incoming requests is propagated even if no exporter is configured
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	cfg := config.Get()
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	var err error
+	switch cfg.TraceExporter {
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if cfg.TraceEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.TraceEndpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case "stdout":
+		w := io.Writer(os.Stdout)
+		if cfg.TraceFile != "" {
+			var f *os.File
+			if f, err = os.OpenFile(cfg.TraceFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644); err != nil {
+				return nil, fmt.Errorf("failed to open trace file: %w", err)
+			}
+			w, closer = f, f
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		return func(context.Context) error { return nil }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	slog.Info("tracing enabled", "exporter", cfg.TraceExporter, "sample_ratio", cfg.TraceSampleRatio)
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
+
+// RecordError marks a span as failed
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
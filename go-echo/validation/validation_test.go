@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/keith-cullen/microservice/problem"
+)
+
+// testSpec has an endpoint that takes a thing with a required name and returns it
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: test
+  version: "1"
+paths:
+  /things/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: dry_run
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Thing"
+      responses:
+        "200":
+          description: the thing
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Thing"
+components:
+  schemas:
+    Thing:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 1
+        attributes:
+          type: object
+          properties:
+            size:
+              type: integer
+`
+
+const testID = "6f1f1f3c-4d4e-4c1a-9a39-1b2b3c4d5e6f"
+
+func newTestValidator(t *testing.T, strict bool) *Validator {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := New(spec, strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// serve sends a request through the middleware to a handler that responds with a status and body
+func serve(v *Validator, method, target, body string, status int, response string) *httptest.ResponseRecorder {
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Handled", "true")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestRequestValidation(t *testing.T) {
+	v := newTestValidator(t, false)
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		fields []string
+	}{
+		{"valid", http.MethodPut, "/things/" + testID, `{"name":"bob"}`, http.StatusOK, nil},
+		{"missing field", http.MethodPut, "/things/" + testID, `{}`, http.StatusBadRequest, []string{"name"}},
+		{"invalid field", http.MethodPut, "/things/" + testID, `{"name":"bob","attributes":{"size":"large"}}`, http.StatusBadRequest, []string{"attributes.size"}},
+		{"invalid path parameter", http.MethodPut, "/things/42", `{"name":"bob"}`, http.StatusBadRequest, []string{"id"}},
+		{"invalid query parameter", http.MethodPut, "/things/" + testID + "?dry_run=maybe", `{"name":"bob"}`, http.StatusBadRequest, []string{"dry_run"}},
+		{"several errors", http.MethodPut, "/things/42", `{"name":""}`, http.StatusBadRequest, []string{"id", "name"}},
+		{"malformed body", http.MethodPut, "/things/" + testID, `{`, http.StatusBadRequest, []string{"body"}},
+		{"body too large", http.MethodPut, "/things/" + testID, `{"name":"` + strings.Repeat("a", maxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, nil},
+		// requests that the spec does not describe get the usual errors of the handlers
+		{"unknown path", http.MethodGet, "/other", "", http.StatusOK, nil},
+		{"unknown method", http.MethodDelete, "/things/" + testID, "", http.StatusOK, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(v, tt.method, tt.target, tt.body, http.StatusOK, `{"name":"bob"}`)
+			if w.Code != tt.status {
+				t.Fatalf("got %d: %s", w.Code, w.Body)
+			}
+			if tt.status == http.StatusOK {
+				return
+			}
+			var p problem.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, e := range p.Errors {
+				fields = append(fields, e.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+				t.Errorf("got field errors %+v, want the fields %v", p.Errors, tt.fields)
+			}
+		})
+	}
+}
+
+func TestResponseValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		strict   bool
+		status   int
+		response string
+		want     int
+	}{
+		{"valid", true, http.StatusOK, `{"name":"bob"}`, http.StatusOK},
+		{"invalid body", true, http.StatusOK, `{"name":""}`, http.StatusInternalServerError},
+		{"undocumented status", true, http.StatusTeapot, `{"name":"bob"}`, http.StatusInternalServerError},
+		{"not JSON", true, http.StatusOK, `bob`, http.StatusInternalServerError},
+		// responses are only validated in strict mode
+		{"invalid body when not strict", false, http.StatusOK, `{"name":""}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestValidator(t, tt.strict)
+			w := serve(v, http.MethodPut, "/things/"+testID, `{"name":"bob"}`, tt.status, tt.response)
+			if w.Code != tt.want {
+				t.Fatalf("got %d: %s", w.Code, w.Body)
+			}
+			if tt.want == http.StatusInternalServerError {
+				if contentType := w.Header().Get("Content-Type"); contentType != problem.ContentType {
+					t.Errorf("got Content-Type %q", contentType)
+				}
+				var p problem.Problem
+				if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+					t.Fatal(err)
+				}
+				if p.Detail != "" {
+					t.Errorf("got the detail %q, server errors have none", p.Detail)
+				}
+				return
+			}
+			if w.Body.String() != tt.response || w.Header().Get("X-Handled") != "true" {
+				t.Errorf("got response %q with header %v", w.Body, w.Header())
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xYW2/juPX/Kgf8/4F9qJI4e+kWBvqQzmU3O9NOkGSxBZJgQEvHNjcSySEpJ0bg714c",
+	"kpIli3bi6WYmRftkS6TO/fzO5YHlqtJKonSWjR+YQauVtOgfzoyalFjR31xJh9LRX651KXLuhJJHOtz4",
+	"0+9WSTqz+RwrTv/+3+CUjdn/Ha3pH4VTe9TQXa1WGSvQ5kZoIsfG7I0xykAjRQYcIgso0HFRWlCT3zF3",
+	"wC0UOBUSC5gs4fztK/jxL6Mfr+UJGPxUo3Xg5tzB1H+z4KUovMQgLHD4fjSCu7myCEj8LJTCf4AgpL8L",
+	"U4FlYa8lIxGj3KTWydnpO1zSP22URuNEMFVukDssPnJvoqkyFf1jBXd44ESFLGNuqZGNmXVGyBlbZQzv",
+	"tTBo9/pGFL27dS2K1LWSW/extnsKpO4kGro+ODG4ULd7UrO50sE4wmFlk3TjC24MX3pTk/OEwYKNr5hX",
+	"LcjUUsu6lr5pCYSgIIrBQa/CJR+uZflhysZXu0MyfMZW2aZjb4O3+1F6OUewmBt04BRYlAUI6ePnnwcn",
+	"Z6cH73AJc+QFmgyEg5xLqRxMEAw6I3CBBZTceb02TLJhAuI+1PKm1fNU6toNo/FzIqt1fiXke5QzN2fj",
+	"46e5FWVdkbRuLuTMjg1yH5Th6c4IRwy5Fre4tGNeVEKymwTlncGwEQfbXf9e2IRFIvee2E+Kh91SNWRT",
+	"4rwlCPF4NhQngFkyJTzyDEPuH7xCUFMfZJ9qNEvQ3PAKHcWY5m6+fgZlWhCcqGIZwOzRWGtuRdlSKnUK",
+	"wjZ9NuD8XpdcBuT1wgsLKs9rY1DmrT4R4jPgE4vSwVQZsGgWaCI+J9EznCRzs4/ixIYPywIWnbrAsqfF",
+	"Rcepg9jImJDWcZnjUKoz8lBUN0qSUioefRSJCDh9vUEgoyLoDdaCz3k4OTh93eKPUzAVBFDttVLNkha1",
+	"jrs6YdGfLy/PIBxCrgpcixGq9JqWkA5nGEwjXJmww0VdVdwsNzwP9H0WiXKrJOi54bblFCXbihnEZcrr",
+	"0pEzJqp240nJ5S3b7C5+PT8Fg1NMRV+UofM5iC53CKQmaLtfPZpV/rQxR2vjVHKdo9XDzKrQWj7DBFSs",
+	"EjQuCXOHRLhzRkxqF5+KQpBBeHnWueVMjQmKn9PY9Iz+8NlNjOQVJr+vdbGnTKnOwpPvC5t1LdXTvcf0",
+	"Zpvlt5Tjf8P8j5mysdHOor2hvf9mqw7p+inx3n3Ma2NDNeun1Sv/3sM2pQbdBc1n2AK6isDDbThIZjIx",
+	"f3qBvpw33+2qz5HoVmXPuMvnQ4U+yHLpJY4FxNcMbijr0StE//M5lzMsMuASsNJuCWs/N1NKXiI3ATB6",
+	"kfXi4mODMFUDzGsj3PKCDN42Ue9weVK7hMlOJJycncItLiGmDc1lZx8uLuFocXzku76j2C5lcDcX+Rxm",
+	"hksXrBPaOpiJBUq4m6OkenXHbUOMUXFlYxaqWpO9Y9b22+uQClKS8hPkBk0jb3h626DFL79dDurDCfzy",
+	"2yVYMaOx8k64Ofx88e0Pf87gnH6or3rj/4TZ0ctML22uIS+5qNYatY0w1d/w699dSy4L6LbGdCGEEhmi",
+	"ykBR8PE8R01GbGpQaIcEVWA5FbPaNBI6dYsSFmjENE7l5ARi1G24u7auuOQzrHxiThuv0awbJ11k42is",
+	"tVHnzukwrws5VT7UQoFnJ1oTDZaxBRob7Dg6HB0e+6FCI8nBxuy7w9HhdxT53M19MA2igl7O0GMPhb1X",
+	"5bQgDr4Xtx6Ysv6G4tvRaMd2Yr+tRGd8SCwmPrwL6RbbjDSpVrbOimOdSX4I7cbk1eZQRONnN8mublYE",
+	"XZxw8aqxA11jWtntlgrTL2vbyb+pYvkHmylUulUfbwmjVgMPHf/BrJvZPuGkeBQ6SYIiYUM6GXS1oaT2",
+	"LbCw69715Th1lQ1z4uhBFKsAtiU63Orxc7+fGWbH94lBUsGr6IwXFdDN9Go9VQ/2hBVrqPdNWz/Ysk7g",
+	"PNJOrm6ifSPExJ8CtcGcuzXFvrVet+cZ1BbhpzehngUE9+4BIa1DXhyybNM5Wv+EjqWV80P8Wjv/09Vn",
+	"IP8zQp8fPb4Y6HXXRI9FiNbrzLDBZQ307eu6thcJ/He67eJ/btvmtrDP28dv684+Wd59I95U9w2TJ9ry",
+	"uKr3Lgx9GPmAhnTjbGyJCOG1wam4Z1nSbe3hdsdl+3And3IhbWBt60kkk+be3N6P/4UyDpTxSx0OJfJC",
+	"yBl8c/ANWEWqC+lXFCj9e39xC3+63+Pdhkp/5G32ur2XB72nGP8H8dcj74EoEvvdoUJ/5/eiqiuQdTVB",
+	"E/eDITPDiiVMiikNSlGJLSr8MMpYFSiz8fFoRI9CxsfhpirhZ80/1Qhh2AXHqb2eGlW1s22cg9f7I1wI",
+	"Vdtd8oYvvhpQrAf7NFpkca7yjN8LeTtsG+gtjSq9AT+km8Hyr9d+Q3DNdqq4+rrF5DIuA7a2z+HCs3bP",
+	"nTXRF26e48Jka9O8EQQqMOnT/4IOfVKZaT3aqzSPNs3hs9fh9Ov3zJ+lcbarmIau83nh5EX2i90Uf/aB",
+	"gnjE9WHKCb/6hfFz4kjYXj4JR/7Tnb9vcsQ9fMov56hLnr8UgP/vcsxq1b58aNKQZoVV1j7Gu503zZpg",
+	"dbP61wBIYTOSryQAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+// testCertificate returns a client certificate with a common name and subject alternative names
+func testCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+	uri, err := url.Parse("spiffe://example.org/writer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "writer", Organization: []string{"Example"}},
+		DNSNames:       []string{"writer.example.org"},
+		URIs:           []*url.URL{uri},
+		EmailAddresses: []string{"writer@example.org"},
+	}
+}
+
+func TestFromCertificate(t *testing.T) {
+	identity := FromCertificate(testCertificate(t))
+	if identity.Subject != "CN=writer,O=Example" {
+		t.Errorf("got subject %q", identity.Subject)
+	}
+	want := []string{"writer", "writer.example.org", "spiffe://example.org/writer", "writer@example.org"}
+	if !slices.Equal(identity.Names, want) {
+		t.Errorf("got names %q, want %q", identity.Names, want)
+	}
+	if identity := FromCertificate(&x509.Certificate{DNSNames: []string{"writer.example.org"}}); !slices.Equal(identity.Names, []string{"writer.example.org"}) {
+		t.Errorf("got names %q for a certificate without a common name", identity.Names)
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+	cert := testCertificate(t)
+	tests := []struct {
+		name  string
+		state *tls.ConnectionState
+		want  string
+	}{
+		{"plain HTTP", nil, ""},
+		{"no client certificate", &tls.ConnectionState{}, ""},
+		// a certificate that was requested but not verified gives no identity
+		{"unverified client certificate", &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}, ""},
+		{"verified client certificate", &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}}, "CN=writer,O=Example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/v1/things", nil)
+			r.TLS = tt.state
+			identity := FromRequest(r)
+			if tt.want == "" && identity != nil {
+				t.Errorf("got identity %q, want none", identity)
+			}
+			if tt.want != "" && (identity == nil || identity.Subject != tt.want) {
+				t.Errorf("got identity %v, want %q", identity, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	identity := FromCertificate(testCertificate(t))
+	tests := []struct {
+		name     string
+		identity *Identity
+		allowed  []string
+		want     bool
+	}{
+		{"no allowed names", identity, nil, true},
+		{"anonymous without allowed names", nil, nil, true},
+		{"anonymous", nil, []string{"writer"}, false},
+		{"subject", identity, []string{"CN=writer,O=Example"}, true},
+		{"common name", identity, []string{"writer"}, true},
+		{"DNS name", identity, []string{"writer.example.org"}, true},
+		{"URI", identity, []string{"spiffe://example.org/writer"}, true},
+		{"email address", identity, []string{"writer@example.org"}, true},
+		{"other names", identity, []string{"reader", "example.org"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.identity.Allowed(tt.allowed); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
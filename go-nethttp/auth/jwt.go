@@ -0,0 +1,311 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ScopeThingsRead  = "things:read"
+	ScopeThingsWrite = "things:write"
+)
+
+var (
+	ErrNoToken           = errors.New("no bearer token")
+	ErrInsufficientScope = errors.New("insufficient scope")
+)
+
+// Claims are the claims of a bearer token
+// The scopes are given by a space-separated scope claim or by an scp claim list
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope,omitempty"`
+	Scp   []string `json:"scp,omitempty"`
+}
+
+// Scopes returns the scopes granted by the token
+func (claims *Claims) Scopes() []string {
+	return append(strings.Fields(claims.Scope), claims.Scp...)
+}
+
+// VerifierConfig configures the keys and claims accepted by a Verifier
+type VerifierConfig struct {
+	JWKSFile string        // JWKSFile is a JSON Web Key Set with RS256 and ES256 public keys and HS256 secrets
+	KeyFiles []string      // KeyFiles are PEM-encoded RS256 and ES256 public keys
+	Secret   string        // Secret is an HS256 shared secret
+	Issuer   string        // Issuer is the required iss claim
+	Audience string        // Audience is the required aud claim
+	Leeway   time.Duration // Leeway allows for clock skew when exp and nbf are checked
+}
+
+// Verifier validates JWT bearer tokens
+type Verifier struct {
+	keys   map[string]any // keys maps key IDs to keys, keys without an ID are given a generated one
+	parser *jwt.Parser
+}
+
+// NewVerifier loads the keys that verify bearer tokens
+func NewVerifier(cfg VerifierConfig) (*Verifier, error) {
+	v := &Verifier{
+		keys: map[string]any{},
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(cfg.Leeway),
+		),
+	}
+	var anonymous []any
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			v.keys[kid] = key
+		}
+	}
+	for _, keyFile := range cfg.KeyFiles {
+		key, err := loadPublicKey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		anonymous = append(anonymous, key)
+	}
+	if cfg.Secret != "" {
+		anonymous = append(anonymous, []byte(cfg.Secret))
+	}
+	for i, key := range anonymous {
+		v.keys[fmt.Sprintf("#%d", i)] = key
+	}
+	if len(v.keys) == 0 {
+		return nil, errors.New("no token verification keys")
+	}
+	return v, nil
+}
+
+// Verify validates a token and returns its claims
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// keyFunc selects the keys that may have signed a token
+// A token with a key ID is only verified by that key, other tokens are verified by every key that suits the algorithm
+func (v *Verifier) keyFunc(token *jwt.Token) (any, error) {
+	if kid, ok := token.Header["kid"].(string); ok {
+		if key, ok := v.keys[kid]; ok {
+			return key, nil
+		}
+	}
+	var keys jwt.VerificationKeySet
+	for _, key := range v.keys {
+		if suitsMethod(key, token.Method) {
+			keys.Keys = append(keys.Keys, key)
+		}
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("no key for algorithm: %s", token.Method.Alg())
+	}
+	return keys, nil
+}
+
+// suitsMethod reports whether a key can verify a signing method
+func suitsMethod(key any, method jwt.SigningMethod) bool {
+	switch key.(type) {
+	case []byte:
+		_, ok := method.(*jwt.SigningMethodHMAC)
+		return ok
+	case *rsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodRSA)
+		return ok
+	case *ecdsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodECDSA)
+		return ok
+	default:
+		return false
+	}
+}
+
+// BearerToken returns the bearer token in the Authorization header of a request
+func BearerToken(r *http.Request) (string, error) {
+	return bearerToken(r.Header)
+}
+
+// bearerToken returns the bearer token in the Authorization field of a header
+func bearerToken(header http.Header) (string, error) {
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", ErrNoToken
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// FromClaims returns the identity given by the claims of a bearer token
+func FromClaims(claims *Claims) *Identity {
+	return &Identity{
+		Subject: claims.Subject,
+		Names:   []string{claims.Subject},
+		Scopes:  claims.Scopes(),
+	}
+}
+
+// HasScope reports whether the identity has been granted a scope
+func (identity *Identity) HasScope(scope string) bool {
+	return identity != nil && slices.Contains(identity.Scopes, scope)
+}
+
+// Challenge returns the WWW-Authenticate header value for a failed bearer token authentication
+func Challenge(err error, scope string) string {
+	switch {
+	case errors.Is(err, ErrNoToken):
+		return fmt.Sprintf("Bearer scope=%q", scope)
+	case errors.Is(err, ErrInsufficientScope):
+		return fmt.Sprintf("Bearer error=\"insufficient_scope\", scope=%q", scope)
+	default:
+		return "Bearer error=\"invalid_token\""
+	}
+}
+
+// jsonWebKey holds the members of a JSON Web Key that are needed to verify RS256, ES256 and HS256 signatures
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// loadJWKS reads a JSON Web Key Set and returns its keys by key ID
+func loadJWKS(jwksFile string) (map[string]any, error) {
+	content, err := os.ReadFile(jwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %s: %w", jwksFile, err)
+	}
+	keys := map[string]any{}
+	for i, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWKS: %s: key %d: %w", jwksFile, i, err)
+		}
+		kid := jwk.Kid
+		if kid == "" {
+			kid = fmt.Sprintf("%s#%d", jwksFile, i)
+		}
+		keys[kid] = key
+	}
+	return keys, nil
+}
+
+// key converts a JSON Web Key to a key that verifies signatures
+func (jwk *jsonWebKey) key() (any, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid n: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid e: %w", err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y: %w", err)
+		}
+		// the uncompressed point encoding is parsed to check that the point is on the curve
+		point := append([]byte{4}, append(leftPad(x, 32), leftPad(y, 32)...)...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "oct":
+		k, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil {
+			return nil, fmt.Errorf("invalid k: %w", err)
+		}
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.Kty)
+	}
+}
+
+// leftPad pads a big-endian integer with leading zeros
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// loadPublicKey reads a PEM-encoded RSA or ECDSA public key or certificate
+func loadPublicKey(keyFile string) (crypto.PublicKey, error) {
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse public key: %s: no PEM data", keyFile)
+	}
+	var key any
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %s: %w", keyFile, err)
+		}
+		key = cert.PublicKey
+	} else if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %s: %w", keyFile, err)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("failed to parse public key: %s: unsupported key type: %T", keyFile, key)
+	}
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/config"
+)
+
+// captureLogs makes the default logger write JSON records to a buffer for the rest of a test
+// The configuration is given in the environment
+func captureLogs(t *testing.T, env map[string]string) *bytes.Buffer {
+	t.Helper()
+	t.Setenv(config.EnvName("LogFormat"), "json")
+	for key, value := range env {
+		t.Setenv(config.EnvName(key), value)
+	}
+	if err := config.Open(""); err != nil {
+		t.Fatal(err)
+	}
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	var buf bytes.Buffer
+	Setup(&buf)
+	return &buf
+}
+
+// records returns the log records written to a buffer
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("failed to decode log record: %v: %s", err, scanner.Bytes())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// serve sends a request to a handler that logs a record and writes a body
+func serve(r *http.Request) *httptest.ResponseRecorder {
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.InfoContext(r.Context(), "handled")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestMiddlewareRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		honoured bool
+	}{
+		{"incoming", "3f2a-client-id", true},
+		{"none", "", false},
+		{"control character", "bad\tid", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLogs(t, nil)
+			r := httptest.NewRequest(http.MethodPost, "/v1/things", nil)
+			if tt.incoming != "" {
+				r.Header.Set(HeaderRequestID, tt.incoming)
+			}
+			w := serve(r)
+			requestID := w.Header().Get(HeaderRequestID)
+			if tt.honoured && requestID != tt.incoming {
+				t.Fatalf("got request ID %q, want %q", requestID, tt.incoming)
+			}
+			if !tt.honoured {
+				if _, err := uuid.Parse(requestID); err != nil {
+					t.Fatalf("got request ID %q, want a generated one", requestID)
+				}
+			}
+			got := records(t, buf)
+			if len(got) != 2 {
+				t.Fatalf("got %d records, want the handler's and the access log record", len(got))
+			}
+			for _, record := range got {
+				if record["request_id"] != requestID {
+					t.Errorf("record %q has request ID %v, want %q", record["msg"], record["request_id"], requestID)
+				}
+			}
+			access := got[1]
+			if access["msg"] != "request" || access["method"] != http.MethodPost || access["path"] != "/v1/things" ||
+				access["status"] != float64(http.StatusCreated) || access["bytes"] != float64(len("hello")) {
+				t.Errorf("got access log record %v", access)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRemoteIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{"direct", "192.0.2.1:1234", "", "192.0.2.1"},
+		{"untrusted peer", "192.0.2.1:1234", "198.51.100.1", "192.0.2.1"},
+		{"trusted proxy", "10.0.0.1:1234", "198.51.100.1", "198.51.100.1"},
+		{"spoofed by the client", "10.0.0.1:1234", "203.0.113.7, 198.51.100.1", "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLogs(t, map[string]string{"TrustedProxies": "10.0.0.0/8"})
+			r := httptest.NewRequest(http.MethodGet, "/v1/things", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			serve(r)
+			for _, record := range records(t, buf) {
+				if record["remote_ip"] != tt.want {
+					t.Errorf("record %q has remote IP %v, want %s", record["msg"], record["remote_ip"], tt.want)
+				}
+			}
+		})
+	}
+}
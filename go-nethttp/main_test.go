@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/keith-cullen/microservice/config"
+)
+
+func TestMain(m *testing.M) {
+	// the signals sent by the tests are also delivered here, so that a signal that is sent
+	// before the code under test has been notified of it does not terminate the tests
+	signal.Notify(make(chan os.Signal, 1), syscall.SIGHUP, syscall.SIGTERM)
+	os.Exit(m.Run())
+}
+
+// signalUntil sends a signal to the process until a value is received, since the code under test
+// may not have been notified of the signal when it is first sent
+func signalUntil[T any](t *testing.T, sig syscall.Signal, ch <-chan T) T {
+	t.Helper()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(10 * time.Second)
+	for {
+		if err := syscall.Kill(os.Getpid(), sig); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case v := <-ch:
+			return v
+		case <-ticker.C:
+		case <-timeout:
+			t.Fatalf("%s was not handled", sig)
+		}
+	}
+}
+
+func TestReloadOnHangup(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(filename, []byte(`ReqPerSec: "10"`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { config.Open("") })
+	reloaded := make(chan *config.Config, 1)
+	config.OnReload(func(c *config.Config) {
+		select {
+		case reloaded <- c:
+		default:
+		}
+	})
+	go reloadOnHangup()
+	if err := os.WriteFile(filename, []byte(`ReqPerSec: "20"`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if c := signalUntil(t, syscall.SIGHUP, reloaded); c.ReqPerSec != 20 {
+		t.Errorf("got ReqPerSec %d, want 20", c.ReqPerSec)
+	}
+}
+
+// runWith runs the application in the background with arguments and a configuration given in the environment
+// The database is a new SQLite file, and the server listens on a free port of the loopback interface unless Addr is given
+func runWith(t *testing.T, env map[string]string, args ...string) <-chan int {
+	t.Helper()
+	t.Setenv(config.EnvName("DatabaseDSN"), "file:"+filepath.Join(t.TempDir(), "store.db")+"?_fk=1")
+	t.Setenv(config.EnvName("Addr"), freeAddr(t))
+	for key, value := range env {
+		t.Setenv(config.EnvName(key), value)
+	}
+	previous := os.Args
+	t.Cleanup(func() { os.Args = previous })
+	os.Args = append([]string{"microservice"}, args...)
+	code := make(chan int, 1)
+	go func() { code <- run() }()
+	return code
+}
+
+// exitCode waits for the application to exit
+func exitCode(t *testing.T, code <-chan int) int {
+	t.Helper()
+	select {
+	case c := <-code:
+		return c
+	case <-time.After(10 * time.Second):
+		t.Fatal("the application did not exit")
+		return 0
+	}
+}
+
+// freeAddr returns an address of the loopback interface that nothing listens on
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+// waitServing waits until the application serves requests on an address
+func waitServing(t *testing.T, addr string) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		resp, err := http.Get("http://" + addr + "/healthz")
+		if err == nil {
+			resp.Body.Close()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the application does not serve requests: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want int
+	}{
+		{"invalid configuration", map[string]string{"LogFormat": "xml"}, []string{"-i"}, exitError},
+		{"command", nil, []string{"migrate", "up"}, exitOK},
+		{"unknown command", nil, []string{"migrate", "sideways"}, exitError},
+		{"certificate not found", map[string]string{"Cert": "missing.pem", "Privkey": "missing.pem"}, nil, exitServe},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(t, runWith(t, tt.env, tt.args...)); got != tt.want {
+				t.Errorf("got exit code %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeAddressInUse(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if got := exitCode(t, runWith(t, map[string]string{"Addr": l.Addr().String()}, "-i")); got != exitServe {
+		t.Errorf("got exit code %d, want %d", got, exitServe)
+	}
+}
+
+func TestExitCodeStopped(t *testing.T) {
+	code := runWith(t, nil, "-i")
+	waitServing(t, os.Getenv(config.EnvName("Addr")))
+	if got := signalUntil(t, syscall.SIGTERM, code); got != exitOK {
+		t.Errorf("got exit code %d, want %d", got, exitOK)
+	}
+}
+
+func TestExitCodeShutdownTimeout(t *testing.T) {
+	code := runWith(t, map[string]string{"ShutdownTimeout": "100ms"}, "-i")
+	addr := os.Getenv(config.EnvName("Addr"))
+	waitServing(t, addr)
+	// a request whose body is never completed is still in flight when the shutdown timeout expires
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "POST /v1/things HTTP/1.1\r\nHost: %s\r\nContent-Type: application/json\r\nContent-Length: 100\r\n\r\n{\"name\":", addr)
+	time.Sleep(100 * time.Millisecond)
+	if got := signalUntil(t, syscall.SIGTERM, code); got != exitShutdown {
+		t.Errorf("got exit code %d, want %d", got, exitShutdown)
+	}
+}
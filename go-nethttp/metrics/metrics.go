@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "microservice"
+
+// registry holds the metrics of the application and of the Go runtime and process
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = newCounterVec("http_requests_total", "Number of HTTP requests by route, method and status", "route", "method", "status")
+	httpDuration = newHistogramVec("http_request_duration_seconds", "Latency of HTTP requests by route, method and status", "route", "method", "status")
+	rateLimited  = newCounterVec("rate_limiter_requests_total", "Number of rate limiter decisions by result", "result")
+	storeOps     = newHistogramVec("store_operation_duration_seconds", "Latency of store operations by operation", "operation")
+	storeErrors  = newCounterVec("store_operation_errors_total", "Number of failed store operations by operation", "operation")
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+func newCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Name: name, Help: help}, labels)
+	registry.MustRegister(c)
+	return c
+}
+
+func newHistogramVec(name, help string, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{Namespace: namespace, Name: name, Help: help, Buckets: prometheus.DefBuckets}, labels)
+	registry.MustRegister(h)
+	return h
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RateLimited records a rate limiter decision
+func RateLimited(allowed bool) {
+	if allowed {
+		rateLimited.WithLabelValues("allowed").Inc()
+	} else {
+		rateLimited.WithLabelValues("denied").Inc()
+	}
+}
+
+// RateLimiterFailed records a request that was allowed because the rate limiter store failed
+func RateLimiterFailed() {
+	rateLimited.WithLabelValues("error").Inc()
+}
+
+// RegisterRateLimiterBuckets exposes the number of active rate limiter token buckets
+// A rate limiter that is created later replaces the previous one
+func RegisterRateLimiterBuckets(buckets func() int) {
+	replace(&rateLimiterBuckets, prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rate_limiter_buckets",
+		Help:      "Number of active rate limiter token buckets",
+	}, func() float64 {
+		return float64(buckets())
+	}))
+}
+
+// ObserveStore records the latency and outcome of a store operation
+func ObserveStore(operation string, duration time.Duration, failed bool) {
+	storeOps.WithLabelValues(operation).Observe(duration.Seconds())
+	if failed {
+		storeErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// The collectors of the objects that are registered at runtime, they are replaced if the objects are created again
+var (
+	replaceMu          sync.Mutex
+	rateLimiterBuckets prometheus.Collector
+	dbStats            prometheus.Collector
+)
+
+// replace registers a collector in place of the one that was registered for the same object before
+func replace(registered *prometheus.Collector, c prometheus.Collector) {
+	replaceMu.Lock()
+	defer replaceMu.Unlock()
+	if *registered != nil {
+		registry.Unregister(*registered)
+	}
+	*registered = c
+	registry.MustRegister(c)
+}
+
+// RegisterDB exposes the connection pool statistics of a database
+// A database that is opened later, such as by a store that is opened again, replaces the previous one
+func RegisterDB(db *sql.DB) {
+	replace(&dbStats, collectors.NewDBStatsCollector(db, "store"))
+}
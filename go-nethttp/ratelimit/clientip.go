@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+const (
+	headerForwarded     = "Forwarded"
+	headerXForwardedFor = "X-Forwarded-For"
+)
+
+// ClientIP returns the address of the client that sent a request
+// The forwarding headers are only used if the request comes from a trusted proxy, they are read from right to left,
+// the last address that is not a trusted proxy is the client, since the addresses to its left could have been forged by it
+// The Forwarded header takes precedence over the X-Forwarded-For header
+func ClientIP(r *http.Request, trustedProxies []netip.Prefix) (netip.Addr, error) {
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to parse remote address: %q: %w", r.RemoteAddr, err)
+	}
+	client := addrPort.Addr().Unmap()
+	if !trusted(client, trustedProxies) {
+		return client, nil
+	}
+	hops := forwardedFor(r.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := parseHop(hops[i])
+		if err != nil {
+			// an address that cannot be parsed cannot be attributed, so the proxy that added it is held responsible
+			return client, nil
+		}
+		client = addr
+		if !trusted(client, trustedProxies) {
+			break
+		}
+	}
+	return client, nil
+}
+
+// trusted reports whether an address is one of the trusted proxies
+func trusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	return slices.ContainsFunc(trustedProxies, func(prefix netip.Prefix) bool {
+		return prefix.Contains(addr)
+	})
+}
+
+// forwardedFor returns the addresses of the clients and proxies that forwarded a request, the client first
+func forwardedFor(header http.Header) []string {
+	var hops []string
+	if values := header.Values(headerForwarded); len(values) > 0 {
+		for _, value := range values {
+			for _, element := range strings.Split(value, ",") {
+				for _, pair := range strings.Split(element, ";") {
+					name, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+					if strings.EqualFold(name, "for") {
+						hops = append(hops, strings.Trim(value, `"`))
+					}
+				}
+			}
+		}
+		return hops
+	}
+	for _, value := range header.Values(headerXForwardedFor) {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// parseHop parses an address in a forwarding header, with or without a port
+// IPv6 addresses are bracketed if they have a port, and always in the Forwarded header
+func parseHop(hop string) (netip.Addr, error) {
+	if addrPort, err := netip.ParseAddrPort(hop); err == nil {
+		return addrPort.Addr().Unmap(), nil
+	}
+	addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]"))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Headers are the response headers that are exposed to the scripts of cross origin clients
+var Headers = []string{HeaderLimit, HeaderRemaining, HeaderReset, HeaderRetryAfter}
+
+// SetHeaders adds the rate limit headers of the IETF httpapi draft to a response, and Retry-After if the request was denied
+// The times are in whole seconds, rounded up so that a client that waits for them is not denied again
+// There are no headers if the decision was not made by the store
+func (r Result) SetHeaders(header http.Header) {
+	if r.Limit == 0 {
+		return
+	}
+	header.Set(HeaderLimit, strconv.Itoa(r.Limit))
+	header.Set(HeaderRemaining, strconv.Itoa(r.Remaining))
+	header.Set(HeaderReset, strconv.Itoa(seconds(r.ResetAfter)))
+	if !r.Allowed {
+		header.Set(HeaderRetryAfter, strconv.Itoa(max(seconds(r.RetryAfter), 1)))
+	}
+}
+
+// seconds returns a duration in whole seconds, rounded up
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSetHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		want   map[string]string
+	}{
+		{"allowed", Result{Allowed: true, Limit: 20, Remaining: 19, ResetAfter: 100 * time.Millisecond},
+			map[string]string{HeaderLimit: "20", HeaderRemaining: "19", HeaderReset: "1", HeaderRetryAfter: ""}},
+		{"denied", Result{Limit: 20, RetryAfter: 1500 * time.Millisecond, ResetAfter: 2 * time.Second},
+			map[string]string{HeaderLimit: "20", HeaderRemaining: "0", HeaderReset: "2", HeaderRetryAfter: "2"}},
+		// a client that retries as soon as it is told to must not be told to retry immediately
+		{"denied briefly", Result{Limit: 20, RetryAfter: time.Millisecond, ResetAfter: time.Millisecond},
+			map[string]string{HeaderLimit: "20", HeaderRemaining: "0", HeaderReset: "1", HeaderRetryAfter: "1"}},
+		{"store failed", Result{Allowed: true},
+			map[string]string{HeaderLimit: "", HeaderRemaining: "", HeaderReset: "", HeaderRetryAfter: ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			tt.result.SetHeaders(header)
+			for name, want := range tt.want {
+				if got := header.Get(name); got != want {
+					t.Errorf("%s: got %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
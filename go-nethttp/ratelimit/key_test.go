@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keith-cullen/microservice/auth"
+	"github.com/keith-cullen/microservice/config"
+)
+
+func TestForRequest(t *testing.T) {
+	t.Setenv(config.EnvName("ReqPerSec"), "10")
+	t.Setenv(config.EnvName("BurstSize"), "20")
+	t.Setenv(config.EnvName("TenantRateLimits"), "ci=100/200")
+	t.Setenv(config.EnvName("RouteRateLimits"), "POST /v1/set=1/5,/v1/things=2/4")
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := &auth.Identity{Subject: "alice", Names: []string{"alice"}}
+	ci := &auth.Identity{Subject: "build", Names: []string{"build", "ci"}}
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		method   string
+		route    string
+		key      string
+		limits   Limits
+	}{
+		{"anonymous", nil, "GET", "/v1/get", "ip:192.0.2.1", Limits{ReqPerSec: 10, Burst: 20}},
+		{"identity", alice, "GET", "/v1/get", "id:alice", Limits{ReqPerSec: 10, Burst: 20}},
+		{"tenant", ci, "GET", "/v1/get", "id:build", Limits{ReqPerSec: 100, Burst: 200}},
+		{"route with method", alice, "POST", "/v1/set", "POST /v1/set id:alice", Limits{ReqPerSec: 1, Burst: 5}},
+		{"route with another method", alice, "GET", "/v1/set", "id:alice", Limits{ReqPerSec: 10, Burst: 20}},
+		{"route without method", nil, "DELETE", "/v1/things", "/v1/things ip:192.0.2.1", Limits{ReqPerSec: 2, Burst: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, limits := ForRequest(cfg, tt.identity, "192.0.2.1", tt.method, tt.route)
+			if key != tt.key {
+				t.Errorf("got key %q, want %q", key, tt.key)
+			}
+			if limits != tt.limits {
+				t.Errorf("got limits %+v, want %+v", limits, tt.limits)
+			}
+		})
+	}
+	// keys that do not fit the key column of the sql store are hashed
+	key, _ := ForRequest(cfg, &auth.Identity{Subject: strings.Repeat("a", maxKeyLen)}, "192.0.2.1", "GET", "/v1/get")
+	if !strings.HasPrefix(key, "sha256:") || len(key) > maxKeyLen {
+		t.Errorf("got key %q", key)
+	}
+}
@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	cleanupInterval = 30 * time.Second // cleanupInterval is the time between iterations of the cleanupLoop
+	maxAge          = 10 * time.Second // maxAge is the maximum time a rate.Limiter can continue to exist since it was last used
+)
+
+// memoryItem combines a rate.Limiter with a last used value
+type memoryItem struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// MemoryStore maintains a map of keys to token bucket rate limiters in the memory of this replica
+// Each token bucket has a size of the burst size, is initially full, and is refilled at the rate of the limits
+type MemoryStore struct {
+	mu            sync.Mutex             // mu synchronises access to the items map
+	items         map[string]*memoryItem // items is a map of keys to memoryItems
+	cleanupTicker *time.Ticker           // cleanupTicker delivers events that trigger the cleanupLoop
+	done          chan struct{}          // done is closed to stop the cleanupLoop
+	stopOnce      sync.Once
+}
+
+// NewMemoryStore creates a new memory store
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		items:         make(map[string]*memoryItem),
+		cleanupTicker: time.NewTicker(cleanupInterval),
+		done:          make(chan struct{}),
+	}
+	go s.cleanupLoop()
+	return s
+}
+
+// getItem finds an existing memoryItem for a given key or creates a new one
+// The limits of an existing item are changed if they have been changed since it was created
+// It also updates the last used time of the memoryItem
+// This must be called with the mu mutex already held
+func (s *MemoryStore) getItem(key string, limits Limits, now time.Time) *memoryItem {
+	item, exists := s.items[key]
+	if !exists {
+		item = &memoryItem{
+			limiter: rate.NewLimiter(rate.Limit(limits.ReqPerSec), limits.Burst),
+		}
+		s.items[key] = item
+	}
+	if item.limiter.Limit() != rate.Limit(limits.ReqPerSec) {
+		item.limiter.SetLimitAt(now, rate.Limit(limits.ReqPerSec))
+	}
+	if item.limiter.Burst() != limits.Burst {
+		item.limiter.SetBurstAt(now, limits.Burst)
+	}
+	item.lastUsed = now
+	return item
+}
+
+// Allow determines if a request with the given key is allowed or not
+func (s *MemoryStore) Allow(ctx context.Context, key string, limits Limits) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	limiter := s.getItem(key, limits, now).limiter
+	result := Result{
+		Allowed: true,
+		Limit:   limits.Burst,
+	}
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		result.Allowed = false
+		result.RetryAfter = delay
+	}
+	tokens := limiter.TokensAt(now)
+	if tokens > 0 {
+		result.Remaining = int(tokens)
+	}
+	result.ResetAfter = time.Duration((float64(limits.Burst) - tokens) / float64(limits.ReqPerSec) * float64(time.Second))
+	return result, nil
+}
+
+// Buckets returns the number of token buckets, one for each key that has been seen recently
+func (s *MemoryStore) Buckets() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// Close stops the cleanupLoop and its ticker
+func (s *MemoryStore) Close() error {
+	s.stopOnce.Do(func() {
+		s.cleanupTicker.Stop()
+		close(s.done)
+	})
+	return nil
+}
+
+// cleanupLoop periodically deletes expired memoryItems until the store is closed
+func (s *MemoryStore) cleanupLoop() {
+	for {
+		select {
+		case <-s.cleanupTicker.C: // Wait for events on the ticker channel
+			s.cleanup()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// cleanup deletes the memoryItems that have not been used for maxAge
+func (s *MemoryStore) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for key, item := range s.items {
+		age := now.Sub(item.lastUsed)
+		if age > maxAge {
+			slog.Debug("rate limiter expired", "key", key, "age", age)
+			delete(s.items, key)
+		}
+	}
+}
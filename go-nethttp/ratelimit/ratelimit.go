@@ -0,0 +1,113 @@
+// Package ratelimit decides whether requests are allowed by the rate limit of their key
+//
+// The state of the rate limits is held by a LimiterStore, in memory for a single replica
+// or in Redis or the database so that the limits are shared by all of the replicas
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/metrics"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Limits are the rate and burst size of the rate limit of each key
+type Limits struct {
+	ReqPerSec int // ReqPerSec is the sustained rate of requests
+	Burst     int // Burst is the number of requests that are allowed at once after a key has been idle
+}
+
+// interval returns the time between requests at the sustained rate
+func (l Limits) interval() time.Duration {
+	return time.Second / time.Duration(l.ReqPerSec)
+}
+
+// Result is the decision for one request
+type Result struct {
+	Allowed    bool
+	Limit      int           // Limit is the burst size
+	Remaining  int           // Remaining is the number of requests that would be allowed immediately after this one
+	RetryAfter time.Duration // RetryAfter is how long until a request is allowed, zero if this one was allowed
+	ResetAfter time.Duration // ResetAfter is how long until the full burst is available again
+}
+
+// LimiterStore holds the state of the rate limits of the keys
+type LimiterStore interface {
+	// Allow takes a request from the rate limit of a key
+	Allow(ctx context.Context, key string, limits Limits) (Result, error)
+	// Close stops the background work of the store and releases its connections
+	Close() error
+}
+
+// Limiter takes requests from rate limits with a LimiterStore, and traces, counts and logs its decisions
+type Limiter struct {
+	store LimiterStore
+}
+
+// New creates a limiter whose state is held by a store
+func New(store LimiterStore) *Limiter {
+	l := &Limiter{
+		store: store,
+	}
+	// only the memory store knows how many keys it holds
+	if b, ok := store.(interface{ Buckets() int }); ok {
+		metrics.RegisterRateLimiterBuckets(b.Buckets)
+	}
+	return l
+}
+
+// Open creates the limiter that is selected by the configuration
+// The database store keeps its state in the same database as the things
+func Open(cfg *config.Config, db *store.Store) (*Limiter, error) {
+	var s LimiterStore
+	var err error
+	switch cfg.RateLimiterStore {
+	case "memory":
+		s = NewMemoryStore()
+	case "redis":
+		s, err = NewRedisStore(cfg.RateLimiterRedisURL)
+	case "sql":
+		s = NewSQLStore(db)
+	default:
+		err = fmt.Errorf("unknown rate limiter store: %q", cfg.RateLimiterStore)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rate limiter: %w", err)
+	}
+	slog.Info("rate limiter open", "store", cfg.RateLimiterStore)
+	return New(s), nil
+}
+
+// Allow takes a request from the rate limit of a key
+// The limits are given with each request so that they follow configuration reloads
+// Requests are allowed if the store fails, so that an unavailable shared store does not take the service down with it
+func (l *Limiter) Allow(ctx context.Context, key string, limits Limits) Result {
+	ctx, span := tracing.Tracer().Start(ctx, "rate limiter")
+	defer span.End()
+	result, err := l.store.Allow(ctx, key, limits)
+	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.RateLimiterFailed()
+		slog.ErrorContext(ctx, "rate limiter failed, request allowed", "key", key, "error", err)
+		return Result{Allowed: true}
+	}
+	metrics.RateLimited(result.Allowed)
+	span.SetAttributes(attribute.Bool("rate_limiter.allowed", result.Allowed))
+	if result.Allowed {
+		slog.DebugContext(ctx, "rate limiter request allowed", "key", key, "remaining", result.Remaining)
+	} else {
+		slog.InfoContext(ctx, "rate limiter request denied", "key", key, "retry_after", result.RetryAfter)
+	}
+	return result
+}
+
+// Close closes the store
+func (l *Limiter) Close() error {
+	return l.store.Close()
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIKeysService implements the APIKeys service of app.proto
+type APIKeysService struct {
+	UnimplementedAPIKeysServer
+	store *store.Store
+}
+
+func newAPIKey(k *ent.APIKey) *APIKey {
+	return &APIKey{
+		Id:         k.ID.String(),
+		Owner:      k.Owner,
+		Scopes:     k.Scopes,
+		CreatedAt:  timestamppb.New(k.CreatedAt),
+		ExpiresAt:  optionalTimestamp(k.ExpiresAt),
+		LastUsedAt: optionalTimestamp(k.LastUsedAt),
+		RevokedAt:  optionalTimestamp(k.RevokedAt),
+	}
+}
+
+// Convert an optional time to a timestamp that is nil if the time is not set
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func (service *APIKeysService) List(ctx context.Context, req *ListAPIKeysRequest) (*APIKeyList, error) {
+	slog.DebugContext(ctx, "APIKeysList")
+	keys, err := service.store.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	resp := &APIKeyList{Apikeys: make([]*APIKey, 0, len(keys))}
+	for _, k := range keys {
+		resp.Apikeys = append(resp.Apikeys, newAPIKey(k))
+	}
+	return resp, nil
+}
+
+func (service *APIKeysService) Create(ctx context.Context, req *CreateAPIKeyRequest) (*APIKeyCreated, error) {
+	input := req.GetApikey()
+	if input.GetOwner() == "" {
+		return nil, invalidArgument("owner", "must not be empty")
+	}
+	fields := store.APIKeyFields{
+		Owner:  input.GetOwner(),
+		Scopes: append([]string{}, input.GetScopes()...),
+	}
+	if input.GetExpiresAt() != nil {
+		if err := input.GetExpiresAt().CheckValid(); err != nil {
+			return nil, invalidArgument("expires_at", "must be a valid time")
+		}
+		expiresAt := input.GetExpiresAt().AsTime()
+		fields.ExpiresAt = &expiresAt
+	}
+	slog.DebugContext(ctx, "APIKeysCreate", "owner", fields.Owner, "scopes", fields.Scopes)
+	k, secret, err := service.store.CreateAPIKey(ctx, fields)
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	created := newAPIKey(k)
+	return &APIKeyCreated{
+		Id:        created.GetId(),
+		Owner:     created.GetOwner(),
+		Scopes:    created.GetScopes(),
+		CreatedAt: created.GetCreatedAt(),
+		ExpiresAt: created.GetExpiresAt(),
+		Key:       secret,
+	}, nil
+}
+
+func (service *APIKeysService) Revoke(ctx context.Context, req *RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "APIKeysRevoke", "id", id)
+	if err := service.store.RevokeAPIKey(ctx, id); err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
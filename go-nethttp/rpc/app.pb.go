@@ -0,0 +1,1324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: app.proto
+
+package rpc
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Req struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Req) Reset() {
+	*x = Req{}
+	mi := &file_app_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Req) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Req) ProtoMessage() {}
+
+func (x *Req) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Req.ProtoReflect.Descriptor instead.
+func (*Req) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Req) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Resp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resp) Reset() {
+	*x = Resp{}
+	mi := &file_app_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resp) ProtoMessage() {}
+
+func (x *Resp) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resp.ProtoReflect.Descriptor instead.
+func (*Resp) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Resp) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Thing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Attributes    *structpb.Struct       `protobuf:"bytes,4,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Thing) Reset() {
+	*x = Thing{}
+	mi := &file_app_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Thing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Thing) ProtoMessage() {}
+
+func (x *Thing) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Thing.ProtoReflect.Descriptor instead.
+func (*Thing) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Thing) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Thing) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Thing) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Thing) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Thing) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Thing) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ThingInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Attributes    *structpb.Struct       `protobuf:"bytes,3,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThingInput) Reset() {
+	*x = ThingInput{}
+	mi := &file_app_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThingInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThingInput) ProtoMessage() {}
+
+func (x *ThingInput) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThingInput.ProtoReflect.Descriptor instead.
+func (*ThingInput) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ThingInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ThingInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ThingInput) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// ThingPatch only changes the fields that are present, an empty attributes object clears the attributes
+type ThingPatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Attributes    *structpb.Struct       `protobuf:"bytes,3,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThingPatch) Reset() {
+	*x = ThingPatch{}
+	mi := &file_app_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThingPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThingPatch) ProtoMessage() {}
+
+func (x *ThingPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThingPatch.ProtoReflect.Descriptor instead.
+func (*ThingPatch) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ThingPatch) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ThingPatch) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ThingPatch) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type ThingList struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Things []*Thing               `protobuf:"bytes,1,rep,name=things,proto3" json:"things,omitempty"`
+	// next_cursor is absent on the last page
+	NextCursor    *string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThingList) Reset() {
+	*x = ThingList{}
+	mi := &file_app_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThingList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThingList) ProtoMessage() {}
+
+func (x *ThingList) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThingList.ProtoReflect.Descriptor instead.
+func (*ThingList) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ThingList) GetThings() []*Thing {
+	if x != nil {
+		return x.Things
+	}
+	return nil
+}
+
+func (x *ThingList) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+type ListThingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// prefix only lists things whose name starts with this prefix
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// contains only lists things whose name contains this substring
+	Contains string `protobuf:"bytes,2,opt,name=contains,proto3" json:"contains,omitempty"`
+	// sort is created_at, name or id, a leading '-' sorts in descending order
+	Sort string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// limit is the maximum number of things in the page
+	Limit *int32 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// cursor is the next_cursor of the previous page
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListThingsRequest) Reset() {
+	*x = ListThingsRequest{}
+	mi := &file_app_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThingsRequest) ProtoMessage() {}
+
+func (x *ListThingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThingsRequest.ProtoReflect.Descriptor instead.
+func (*ListThingsRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListThingsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListThingsRequest) GetContains() string {
+	if x != nil {
+		return x.Contains
+	}
+	return ""
+}
+
+func (x *ListThingsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListThingsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListThingsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type CreateThingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thing         *ThingInput            `protobuf:"bytes,1,opt,name=thing,proto3" json:"thing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateThingRequest) Reset() {
+	*x = CreateThingRequest{}
+	mi := &file_app_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateThingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateThingRequest) ProtoMessage() {}
+
+func (x *CreateThingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateThingRequest.ProtoReflect.Descriptor instead.
+func (*CreateThingRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateThingRequest) GetThing() *ThingInput {
+	if x != nil {
+		return x.Thing
+	}
+	return nil
+}
+
+type GetThingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThingRequest) Reset() {
+	*x = GetThingRequest{}
+	mi := &file_app_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThingRequest) ProtoMessage() {}
+
+func (x *GetThingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThingRequest.ProtoReflect.Descriptor instead.
+func (*GetThingRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetThingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReplaceThingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Thing         *ThingInput            `protobuf:"bytes,2,opt,name=thing,proto3" json:"thing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceThingRequest) Reset() {
+	*x = ReplaceThingRequest{}
+	mi := &file_app_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceThingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceThingRequest) ProtoMessage() {}
+
+func (x *ReplaceThingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceThingRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceThingRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReplaceThingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReplaceThingRequest) GetThing() *ThingInput {
+	if x != nil {
+		return x.Thing
+	}
+	return nil
+}
+
+type UpdateThingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Patch         *ThingPatch            `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateThingRequest) Reset() {
+	*x = UpdateThingRequest{}
+	mi := &file_app_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateThingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateThingRequest) ProtoMessage() {}
+
+func (x *UpdateThingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateThingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateThingRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateThingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateThingRequest) GetPatch() *ThingPatch {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+type DeleteThingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteThingRequest) Reset() {
+	*x = DeleteThingRequest{}
+	mi := &file_app_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteThingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteThingRequest) ProtoMessage() {}
+
+func (x *DeleteThingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteThingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteThingRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteThingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_app_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type APIKeyInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKeyInput) Reset() {
+	*x = APIKeyInput{}
+	mi := &file_app_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKeyInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyInput) ProtoMessage() {}
+
+func (x *APIKeyInput) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyInput.ProtoReflect.Descriptor instead.
+func (*APIKeyInput) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *APIKeyInput) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *APIKeyInput) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKeyInput) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// APIKeyCreated is a new API key with its secret, which cannot be retrieved later
+type APIKeyCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner         string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Key           string                 `protobuf:"bytes,6,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKeyCreated) Reset() {
+	*x = APIKeyCreated{}
+	mi := &file_app_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKeyCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyCreated) ProtoMessage() {}
+
+func (x *APIKeyCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyCreated.ProtoReflect.Descriptor instead.
+func (*APIKeyCreated) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *APIKeyCreated) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKeyCreated) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *APIKeyCreated) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKeyCreated) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKeyCreated) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKeyCreated) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type APIKeyList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Apikeys       []*APIKey              `protobuf:"bytes,1,rep,name=apikeys,proto3" json:"apikeys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKeyList) Reset() {
+	*x = APIKeyList{}
+	mi := &file_app_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKeyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyList) ProtoMessage() {}
+
+func (x *APIKeyList) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyList.ProtoReflect.Descriptor instead.
+func (*APIKeyList) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *APIKeyList) GetApikeys() []*APIKey {
+	if x != nil {
+		return x.Apikeys
+	}
+	return nil
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_app_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{16}
+}
+
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Apikey        *APIKeyInput           `protobuf:"bytes,1,opt,name=apikey,proto3" json:"apikey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_app_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateAPIKeyRequest) GetApikey() *APIKeyInput {
+	if x != nil {
+		return x.Apikey
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_app_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_app_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_app_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevokeAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_app_proto protoreflect.FileDescriptor
+
+const file_app_proto_rawDesc = "" +
+	"\n" +
+	"\tapp.proto\x12\x03app\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\x19\n" +
+	"\x03Req\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1c\n" +
+	"\x04Resp\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\xfc\x01\n" +
+	"\x05Thing\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x127\n" +
+	"\n" +
+	"attributes\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"{\n" +
+	"\n" +
+	"ThingInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x127\n" +
+	"\n" +
+	"attributes\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\"\x9e\x01\n" +
+	"\n" +
+	"ThingPatch\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x127\n" +
+	"\n" +
+	"attributes\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributesB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"e\n" +
+	"\tThingList\x12\"\n" +
+	"\x06things\x18\x01 \x03(\v2\n" +
+	".app.ThingR\x06things\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor\"\x98\x01\n" +
+	"\x11ListThingsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1a\n" +
+	"\bcontains\x18\x02 \x01(\tR\bcontains\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursorB\b\n" +
+	"\x06_limit\";\n" +
+	"\x12CreateThingRequest\x12%\n" +
+	"\x05thing\x18\x01 \x01(\v2\x0f.app.ThingInputR\x05thing\"!\n" +
+	"\x0fGetThingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x13ReplaceThingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x05thing\x18\x02 \x01(\v2\x0f.app.ThingInputR\x05thing\"K\n" +
+	"\x12UpdateThingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x05patch\x18\x02 \x01(\v2\x0f.app.ThingPatchR\x05patch\"$\n" +
+	"\x12DeleteThingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb5\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"v\n" +
+	"\vAPIKeyInput\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xd5\x01\n" +
+	"\rAPIKeyCreated\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x10\n" +
+	"\x03key\x18\x06 \x01(\tR\x03key\"3\n" +
+	"\n" +
+	"APIKeyList\x12%\n" +
+	"\aapikeys\x18\x01 \x03(\v2\v.app.APIKeyR\aapikeys\"\x14\n" +
+	"\x12ListAPIKeysRequest\"?\n" +
+	"\x13CreateAPIKeyRequest\x12(\n" +
+	"\x06apikey\x18\x01 \x01(\v2\x10.app.APIKeyInputR\x06apikey\"%\n" +
+	"\x13RevokeAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2v\n" +
+	"\x03App\x12.\n" +
+	"\x03get\x12\b.app.Req\x1a\t.app.Resp\"\x12\x82\xd3\xe4\x93\x02\t\x12\a/v1/get\x88\x02\x01\x12?\n" +
+	"\x03set\x12\b.app.Req\x1a\x1a.google.protobuf.BoolValue\"\x12\x82\xd3\xe4\x93\x02\t\"\a/v1/set\x88\x02\x012\xcc\x03\n" +
+	"\x06Things\x12B\n" +
+	"\x04List\x12\x16.app.ListThingsRequest\x1a\x0e.app.ThingList\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/things\x12H\n" +
+	"\x06Create\x12\x17.app.CreateThingRequest\x1a\n" +
+	".app.Thing\"\x19\x82\xd3\xe4\x93\x02\x13:\x05thing\"\n" +
+	"/v1/things\x12@\n" +
+	"\x03Get\x12\x14.app.GetThingRequest\x1a\n" +
+	".app.Thing\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/things/{id}\x12O\n" +
+	"\aReplace\x12\x18.app.ReplaceThingRequest\x1a\n" +
+	".app.Thing\"\x1e\x82\xd3\xe4\x93\x02\x18:\x05thing\x1a\x0f/v1/things/{id}\x12M\n" +
+	"\x06Update\x12\x17.app.UpdateThingRequest\x1a\n" +
+	".app.Thing\"\x1e\x82\xd3\xe4\x93\x02\x18:\x05patch2\x0f/v1/things/{id}\x12R\n" +
+	"\x06Delete\x12\x17.app.DeleteThingRequest\x1a\x16.google.protobuf.Empty\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/things/{id}2\x8d\x02\n" +
+	"\aAPIKeys\x12K\n" +
+	"\x04List\x12\x17.app.ListAPIKeysRequest\x1a\x0f.app.APIKeyList\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/admin/apikeys\x12Y\n" +
+	"\x06Create\x12\x18.app.CreateAPIKeyRequest\x1a\x12.app.APIKeyCreated\"!\x82\xd3\xe4\x93\x02\x1b:\x06apikey\"\x11/v1/admin/apikeys\x12Z\n" +
+	"\x06Revoke\x12\x18.app.RevokeAPIKeyRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/v1/admin/apikeys/{id}B*Z(github.com/keith-cullen/microservice/rpcb\x06proto3"
+
+var (
+	file_app_proto_rawDescOnce sync.Once
+	file_app_proto_rawDescData []byte
+)
+
+func file_app_proto_rawDescGZIP() []byte {
+	file_app_proto_rawDescOnce.Do(func() {
+		file_app_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_app_proto_rawDesc), len(file_app_proto_rawDesc)))
+	})
+	return file_app_proto_rawDescData
+}
+
+var file_app_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_app_proto_goTypes = []any{
+	(*Req)(nil),                   // 0: app.Req
+	(*Resp)(nil),                  // 1: app.Resp
+	(*Thing)(nil),                 // 2: app.Thing
+	(*ThingInput)(nil),            // 3: app.ThingInput
+	(*ThingPatch)(nil),            // 4: app.ThingPatch
+	(*ThingList)(nil),             // 5: app.ThingList
+	(*ListThingsRequest)(nil),     // 6: app.ListThingsRequest
+	(*CreateThingRequest)(nil),    // 7: app.CreateThingRequest
+	(*GetThingRequest)(nil),       // 8: app.GetThingRequest
+	(*ReplaceThingRequest)(nil),   // 9: app.ReplaceThingRequest
+	(*UpdateThingRequest)(nil),    // 10: app.UpdateThingRequest
+	(*DeleteThingRequest)(nil),    // 11: app.DeleteThingRequest
+	(*APIKey)(nil),                // 12: app.APIKey
+	(*APIKeyInput)(nil),           // 13: app.APIKeyInput
+	(*APIKeyCreated)(nil),         // 14: app.APIKeyCreated
+	(*APIKeyList)(nil),            // 15: app.APIKeyList
+	(*ListAPIKeysRequest)(nil),    // 16: app.ListAPIKeysRequest
+	(*CreateAPIKeyRequest)(nil),   // 17: app.CreateAPIKeyRequest
+	(*RevokeAPIKeyRequest)(nil),   // 18: app.RevokeAPIKeyRequest
+	(*structpb.Struct)(nil),       // 19: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+	(*wrapperspb.BoolValue)(nil),  // 21: google.protobuf.BoolValue
+	(*emptypb.Empty)(nil),         // 22: google.protobuf.Empty
+}
+var file_app_proto_depIdxs = []int32{
+	19, // 0: app.Thing.attributes:type_name -> google.protobuf.Struct
+	20, // 1: app.Thing.created_at:type_name -> google.protobuf.Timestamp
+	20, // 2: app.Thing.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 3: app.ThingInput.attributes:type_name -> google.protobuf.Struct
+	19, // 4: app.ThingPatch.attributes:type_name -> google.protobuf.Struct
+	2,  // 5: app.ThingList.things:type_name -> app.Thing
+	3,  // 6: app.CreateThingRequest.thing:type_name -> app.ThingInput
+	3,  // 7: app.ReplaceThingRequest.thing:type_name -> app.ThingInput
+	4,  // 8: app.UpdateThingRequest.patch:type_name -> app.ThingPatch
+	20, // 9: app.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	20, // 10: app.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 11: app.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	20, // 12: app.APIKey.revoked_at:type_name -> google.protobuf.Timestamp
+	20, // 13: app.APIKeyInput.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 14: app.APIKeyCreated.created_at:type_name -> google.protobuf.Timestamp
+	20, // 15: app.APIKeyCreated.expires_at:type_name -> google.protobuf.Timestamp
+	12, // 16: app.APIKeyList.apikeys:type_name -> app.APIKey
+	13, // 17: app.CreateAPIKeyRequest.apikey:type_name -> app.APIKeyInput
+	0,  // 18: app.App.get:input_type -> app.Req
+	0,  // 19: app.App.set:input_type -> app.Req
+	6,  // 20: app.Things.List:input_type -> app.ListThingsRequest
+	7,  // 21: app.Things.Create:input_type -> app.CreateThingRequest
+	8,  // 22: app.Things.Get:input_type -> app.GetThingRequest
+	9,  // 23: app.Things.Replace:input_type -> app.ReplaceThingRequest
+	10, // 24: app.Things.Update:input_type -> app.UpdateThingRequest
+	11, // 25: app.Things.Delete:input_type -> app.DeleteThingRequest
+	16, // 26: app.APIKeys.List:input_type -> app.ListAPIKeysRequest
+	17, // 27: app.APIKeys.Create:input_type -> app.CreateAPIKeyRequest
+	18, // 28: app.APIKeys.Revoke:input_type -> app.RevokeAPIKeyRequest
+	1,  // 29: app.App.get:output_type -> app.Resp
+	21, // 30: app.App.set:output_type -> google.protobuf.BoolValue
+	5,  // 31: app.Things.List:output_type -> app.ThingList
+	2,  // 32: app.Things.Create:output_type -> app.Thing
+	2,  // 33: app.Things.Get:output_type -> app.Thing
+	2,  // 34: app.Things.Replace:output_type -> app.Thing
+	2,  // 35: app.Things.Update:output_type -> app.Thing
+	22, // 36: app.Things.Delete:output_type -> google.protobuf.Empty
+	15, // 37: app.APIKeys.List:output_type -> app.APIKeyList
+	14, // 38: app.APIKeys.Create:output_type -> app.APIKeyCreated
+	22, // 39: app.APIKeys.Revoke:output_type -> google.protobuf.Empty
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_app_proto_init() }
+func file_app_proto_init() {
+	if File_app_proto != nil {
+		return
+	}
+	file_app_proto_msgTypes[4].OneofWrappers = []any{}
+	file_app_proto_msgTypes[5].OneofWrappers = []any{}
+	file_app_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_proto_rawDesc), len(file_app_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_app_proto_goTypes,
+		DependencyIndexes: file_app_proto_depIdxs,
+		MessageInfos:      file_app_proto_msgTypes,
+	}.Build()
+	File_app_proto = out.File
+	file_app_proto_goTypes = nil
+	file_app_proto_depIdxs = nil
+}
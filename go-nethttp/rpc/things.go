@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ThingsService implements the Things service of app.proto
+type ThingsService struct {
+	UnimplementedThingsServer
+	store *store.Store
+}
+
+func newThing(ctx context.Context, t *ent.Thing) (*Thing, error) {
+	attributes, err := structpb.NewStruct(t.Attributes)
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return &Thing{
+		Id:          t.ID.String(),
+		Name:        t.Name,
+		Description: t.Description,
+		Attributes:  attributes,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+	}, nil
+}
+
+// Convert the input of a request to the fields of a thing
+func newThingFields(input *ThingInput) store.ThingFields {
+	fields := store.ThingFields{Name: input.GetName(), Description: input.GetDescription()}
+	if input.GetAttributes() != nil {
+		fields.Attributes = input.GetAttributes().AsMap()
+	}
+	return fields
+}
+
+// Parse the ID of a thing or API key
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.UUID{}, invalidArgument("id", "must be a UUID")
+	}
+	return parsed, nil
+}
+
+func (service *ThingsService) List(ctx context.Context, req *ListThingsRequest) (*ThingList, error) {
+	opts := store.ListOptions{
+		Prefix:   req.GetPrefix(),
+		Contains: req.GetContains(),
+		Sort:     req.GetSort(),
+		Cursor:   req.GetCursor(),
+	}
+	if req.Limit != nil {
+		if req.GetLimit() < 1 {
+			return nil, invalidArgument("limit", "must be a positive integer")
+		}
+		opts.Limit = int(req.GetLimit())
+	}
+	slog.DebugContext(ctx, "ThingsList", "options", opts)
+	page, err := service.store.ListThings(ctx, opts)
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	resp := &ThingList{Things: make([]*Thing, 0, len(page.Things))}
+	for _, t := range page.Things {
+		thing, err := newThing(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		resp.Things = append(resp.Things, thing)
+	}
+	if page.NextCursor != "" {
+		resp.NextCursor = &page.NextCursor
+	}
+	return resp, nil
+}
+
+func (service *ThingsService) Create(ctx context.Context, req *CreateThingRequest) (*Thing, error) {
+	if req.GetThing().GetName() == "" {
+		return nil, invalidArgument("name", "must not be empty")
+	}
+	slog.DebugContext(ctx, "ThingsCreate", "name", req.GetThing().GetName())
+	t, err := service.store.CreateThing(ctx, newThingFields(req.GetThing()))
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return newThing(ctx, t)
+}
+
+func (service *ThingsService) Get(ctx context.Context, req *GetThingRequest) (*Thing, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "ThingsGet", "id", id)
+	t, err := service.store.GetThingByID(ctx, id)
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return newThing(ctx, t)
+}
+
+func (service *ThingsService) Replace(ctx context.Context, req *ReplaceThingRequest) (*Thing, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetThing().GetName() == "" {
+		return nil, invalidArgument("name", "must not be empty")
+	}
+	slog.DebugContext(ctx, "ThingsReplace", "id", id, "name", req.GetThing().GetName())
+	t, err := service.store.ReplaceThing(ctx, id, newThingFields(req.GetThing()))
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return newThing(ctx, t)
+}
+
+func (service *ThingsService) Update(ctx context.Context, req *UpdateThingRequest) (*Thing, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	patch := req.GetPatch()
+	if patch == nil {
+		// a request without a body changes nothing
+		patch = &ThingPatch{}
+	}
+	if patch.Name != nil && patch.GetName() == "" {
+		return nil, invalidArgument("name", "must not be empty")
+	}
+	slog.DebugContext(ctx, "ThingsUpdate", "id", id)
+	thingPatch := store.ThingPatch{
+		Name:        patch.Name,
+		Description: patch.Description,
+	}
+	if patch.GetAttributes() != nil {
+		thingPatch.Attributes = patch.GetAttributes().AsMap()
+	}
+	t, err := service.store.PatchThing(ctx, id, thingPatch)
+	if err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return newThing(ctx, t)
+}
+
+func (service *ThingsService) Delete(ctx context.Context, req *DeleteThingRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "ThingsDelete", "id", id)
+	if err := service.store.DeleteThing(ctx, id); err != nil {
+		return nil, storeError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
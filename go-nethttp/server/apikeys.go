@@ -0,0 +1,101 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+)
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type APIKeyInput struct {
+	Owner     string     `json:"owner"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyCreated is only sent when a key is created because the key cannot be retrieved later
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyList struct {
+	APIKeys []APIKey `json:"apikeys"`
+}
+
+func newAPIKey(k *ent.APIKey) APIKey {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return APIKey{
+		ID:         k.ID,
+		Owner:      k.Owner,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func (handler Handler) APIKeysList(w http.ResponseWriter, r *http.Request) {
+	slog.DebugContext(r.Context(), "APIKeysList")
+	keys, err := handler.store.ListAPIKeys(r.Context())
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	resp := APIKeyList{APIKeys: make([]APIKey, 0, len(keys))}
+	for _, k := range keys {
+		resp.APIKeys = append(resp.APIKeys, newAPIKey(k))
+	}
+	respondJSON(w, r, http.StatusOK, resp)
+}
+
+func (handler Handler) APIKeysCreate(w http.ResponseWriter, r *http.Request) {
+	var input APIKeyInput
+	if err := decodeBody(w, r, &input); err != nil {
+		respondBodyError(w, r, err)
+		return
+	}
+	if input.Owner == "" {
+		respondInvalid(w, r, problem.FieldError{Field: "owner", Detail: "must not be empty"})
+		return
+	}
+	slog.DebugContext(r.Context(), "APIKeysCreate", "owner", input.Owner, "scopes", input.Scopes)
+	k, secret, err := handler.store.CreateAPIKey(r.Context(), store.APIKeyFields(input))
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	respondJSON(w, r, http.StatusCreated, APIKeyCreated{APIKey: newAPIKey(k), Key: secret})
+}
+
+func (handler Handler) APIKeysRevoke(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respondInvalid(w, r, invalidID)
+		return
+	}
+	slog.DebugContext(r.Context(), "APIKeysRevoke", "id", id)
+	if err := handler.store.RevokeAPIKey(r.Context(), id); err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
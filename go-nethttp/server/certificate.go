@@ -0,0 +1,136 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	certWatchDelay   = 100 * time.Millisecond // certWatchDelay lets a burst of file events settle before the files are reloaded
+	certExpiryWarnAt = 30 * 24 * time.Hour    // certExpiryWarnAt is how long before expiry a warning is logged
+)
+
+// CertReloader serves a TLS certificate and reloads it when the certificate or private key file changes
+// A new certificate is only used if it can be parsed and matches its private key
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Pointer[tls.Certificate]
+	watcher  *fsnotify.Watcher
+}
+
+// NewCertReloader loads a certificate and starts watching its files
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch certificate: %w", err)
+	}
+	// the directories are watched so that files that are replaced rather than rewritten are followed
+	for _, dir := range []string{filepath.Dir(certFile), filepath.Dir(keyFile)} {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("failed to watch certificate: %w", err)
+		}
+	}
+	r.watcher = watcher
+	go r.watchLoop()
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
+
+// NotAfter returns the expiry time of the current certificate
+func (r *CertReloader) NotAfter() time.Time {
+	return r.cert.Load().Leaf.NotAfter
+}
+
+// Close stops watching the certificate files
+func (r *CertReloader) Close() error {
+	return r.watcher.Close()
+}
+
+// load reads and validates the certificate and private key and makes them current
+func (r *CertReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+	}
+	r.cert.Store(&cert)
+	notAfter := cert.Leaf.NotAfter
+	slog.Info("loaded certificate", "subject", cert.Leaf.Subject.String(), "expires", notAfter.Format(time.RFC3339))
+	if remaining := time.Until(notAfter); remaining < certExpiryWarnAt {
+		slog.Warn("certificate expires soon", "remaining", remaining.Round(time.Second))
+	}
+	return nil
+}
+
+// watchLoop reloads the certificate after events for the certificate or private key file
+func (r *CertReloader) watchLoop() {
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			name := filepath.Clean(event.Name)
+			if name != filepath.Clean(r.certFile) && name != filepath.Clean(r.keyFile) &&
+				filepath.Base(name) != "..data" { // Kubernetes swaps the ..data symlink
+				continue
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(certWatchDelay, func() {
+				// the current certificate is kept if the new one is invalid, e.g. if only one of the files has been replaced so far
+				if err := r.load(); err != nil {
+					slog.Error("keeping the current certificate", "error", err)
+				}
+			})
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("certificate watcher error", "error", err)
+		}
+	}
+}
+
+// loadClientCAs reads the CA bundle that verifies client certificates
+func loadClientCAs(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("failed to parse client CA bundle: %s", caFile)
+	}
+	return pool, nil
+}
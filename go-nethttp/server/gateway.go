@@ -0,0 +1,35 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/keith-cullen/microservice/rpc"
+)
+
+// registerGateway serves the REST endpoints bound in app.proto with grpc-gateway in place of the handlers
+func registerGateway(router *mux.Router, services *rpc.Services) error {
+	gateway, err := rpc.NewGateway(services, "application/json")
+	if err != nil {
+		return err
+	}
+	for _, route := range rpc.Routes() {
+		router.Handle(route.Path, limitBody(gateway.Handler(route))).Methods(route.Method)
+	}
+	return nil
+}
+
+// limitBody reads the body of a request up to the size that the handlers accept, a larger body is rejected as they reject it
+func limitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			respondBodyError(w, r, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
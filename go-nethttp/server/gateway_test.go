@@ -0,0 +1,213 @@
+package server
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+	"github.com/keith-cullen/microservice/api"
+	"github.com/keith-cullen/microservice/rpc"
+)
+
+// TestGatewayWireFormat checks that the endpoints served by grpc-gateway respond like the handlers that they replace
+func TestGatewayWireFormat(t *testing.T) {
+	// {id} is replaced by the ID of the thing created by the first request that creates one
+	requests := []struct {
+		method string
+		target string
+		body   any
+	}{
+		{http.MethodPost, "/v1/set?name=bob", nil},
+		{http.MethodPost, "/v1/set?name=bob", nil},
+		{http.MethodGet, "/v1/get?name=bob", nil},
+		{http.MethodGet, "/v1/get?name=alice", nil},
+		{http.MethodGet, "/v1/get", nil},
+		{http.MethodPost, "/v1/set", nil},
+		{http.MethodPost, "/v1/things", map[string]any{"name": "alice", "attributes": map[string]any{"colour": "red", "size": 3}}},
+		{http.MethodPost, "/v1/things", map[string]any{"name": "alice"}},
+		{http.MethodPost, "/v1/things", map[string]any{}},
+		{http.MethodPost, "/v1/things", "alice"},
+		{http.MethodGet, "/v1/things/{id}", nil},
+		{http.MethodPut, "/v1/things/{id}", map[string]any{"name": "carol", "description": "replaced"}},
+		{http.MethodPatch, "/v1/things/{id}", map[string]any{"description": "patched", "attributes": map[string]any{}}},
+		{http.MethodPatch, "/v1/things/{id}", map[string]any{"name": ""}},
+		{http.MethodGet, "/v1/things?limit=1&sort=name", nil},
+		{http.MethodGet, "/v1/things?limit=0", nil},
+		{http.MethodGet, "/v1/things?sort=size", nil},
+		{http.MethodDelete, "/v1/things/{id}", nil},
+		{http.MethodGet, "/v1/things/{id}", nil},
+		{http.MethodPost, "/v1/admin/apikeys", map[string]any{"owner": "ci", "scopes": []string{"things:read"}, "expires_at": "2100-01-02T03:04:05Z"}},
+		{http.MethodPost, "/v1/admin/apikeys", map[string]any{"owner": "ci", "scopes": []string{"things:delete"}}},
+		{http.MethodPost, "/v1/admin/apikeys", map[string]any{"scopes": []string{}}},
+		{http.MethodGet, "/v1/admin/apikeys", nil},
+		{http.MethodDelete, "/v1/admin/apikeys/6f1f1f3c-4d4e-4c1a-9a39-1b2b3c4d5e6f", nil},
+	}
+	type response struct {
+		Status  int
+		Header  http.Header
+		Message any
+	}
+	responses := map[string][]response{}
+	// the requests are checked against the spec by the validator in strict mode, and by the handlers and services otherwise
+	for _, validation := range []string{"strict", "off"} {
+		for _, gateway := range []bool{false, true} {
+			mode := "validation=" + validation + ",gateway=" + strconv.FormatBool(gateway)
+			t.Run(mode, func(t *testing.T) {
+				handler := newTestServer(t, map[string]string{
+					"GRPCGateway":       strconv.FormatBool(gateway),
+					"OpenAPIValidation": validation,
+					"AdminIdentities":   "admin",
+				})
+				admin := client{cert: clientCert(t, newTestCA(t), &x509.Certificate{Subject: pkix.Name{CommonName: "admin"}})}
+				var id string
+				for _, r := range requests {
+					w := serveAs(t, handler, admin, r.method, strings.Replace(r.target, "{id}", id, 1), r.body)
+					var body any
+					if w.Body.Len() > 0 {
+						if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+							t.Fatalf("%s %s: %v: %s", r.method, r.target, err, w.Body)
+						}
+					}
+					thing, _ := body.(map[string]any)
+					if id == "" && w.Code == http.StatusCreated && r.target == "/v1/things" {
+						id, _ = thing["id"].(string)
+					}
+					// the IDs, times, cursors and secrets differ between the servers, so they are compared by their format
+					var replacements []string
+					if id != "" {
+						replacements = append(replacements, id, "{id}")
+					}
+					if cursor, ok := thing["next_cursor"].(string); ok {
+						replacements = append(replacements, cursor, "{cursor}")
+					}
+					replacer := strings.NewReplacer(replacements...)
+					header := http.Header{}
+					for _, name := range []string{"Content-Type", "Deprecation", "Link", "Location"} {
+						for _, value := range w.Header().Values(name) {
+							header.Add(name, replacer.Replace(value))
+						}
+					}
+					responses[mode] = append(responses[mode], response{Status: w.Code, Header: header, Message: normalize(body, replacer)})
+				}
+			})
+		}
+	}
+	for _, validation := range []string{"strict", "off"} {
+		rest, gateway := responses["validation="+validation+",gateway=false"], responses["validation="+validation+",gateway=true"]
+		for i, r := range requests {
+			if !reflect.DeepEqual(rest[i], gateway[i]) {
+				t.Errorf("validation=%s: %s %s: got %+v from the gateway, want %+v", validation, r.method, r.target, gateway[i], rest[i])
+			}
+		}
+	}
+	gateway := responses["validation=off,gateway=true"]
+	if got := gateway[0]; got.Status != http.StatusOK || got.Message.(map[string]any)["message"] != "Hello, bob" || got.Header.Get("Deprecation") != "true" {
+		t.Errorf("set: got %+v", got)
+	}
+	if got := gateway[6]; got.Status != http.StatusCreated || got.Header.Get("Location") != "/v1/things/{id}" || got.Header.Get("Deprecation") != "" {
+		t.Errorf("create: got %+v", got)
+	}
+}
+
+// normalize replaces the values of a response body that differ between servers by their format, and the strings replaced by a replacer,
+// and drops the request ID, which differs for every request
+func normalize(body any, replacer *strings.Replacer) any {
+	switch body := body.(type) {
+	case map[string]any:
+		normalized := map[string]any{}
+		for key, value := range body {
+			if key == "request_id" {
+				continue
+			}
+			normalized[key] = normalize(value, replacer)
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			switch {
+			case key == "id" || key == "next_cursor" || key == "key":
+				normalized[key] = "<" + key + ">"
+			case strings.HasSuffix(key, "_at"):
+				if _, err := time.Parse(time.RFC3339Nano, s); err == nil {
+					normalized[key] = "<time>"
+				}
+			}
+		}
+		return normalized
+	case []any:
+		normalized := make([]any, 0, len(body))
+		for _, value := range body {
+			normalized = append(normalized, normalize(value, replacer))
+		}
+		return normalized
+	case string:
+		return replacer.Replace(body)
+	default:
+		return body
+	}
+}
+
+// operations returns the methods and paths of the operations of an OpenAPI spec
+func operations(spec *openapi3.T) []string {
+	var ops []string
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			ops = append(ops, method+" "+path)
+		}
+	}
+	slices.Sort(ops)
+	return ops
+}
+
+// TestGatewayRoutes checks that the routes that the server serves in gateway mode, and the spec generated from app.proto,
+// are those of openapi.yaml, which the handlers implement
+func TestGatewayRoutes(t *testing.T) {
+	spec, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := operations(spec)
+	server := openTestServer(t, map[string]string{"GRPCGateway": "true"})
+	served := map[string]bool{}
+	err = server.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		// the root route serves the not found response for every method
+		methods, _ := route.GetMethods()
+		for _, method := range methods {
+			served[method+" "+path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Sorted(maps.Keys(served)); !slices.Equal(got, want) {
+		t.Errorf("the gateway serves %v, want the operations of openapi.yaml %v", got, want)
+	}
+	generated, err := rpc.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := operations(generated); !slices.Equal(got, want) {
+		t.Errorf("the spec generated from app.proto describes %v, want the operations of openapi.yaml %v", got, want)
+	}
+	// the deprecated methods of app.proto are the deprecated operations of openapi.yaml
+	for _, route := range rpc.Routes() {
+		if op := spec.Paths.Find(route.Path).GetOperation(route.Method); op == nil || op.Deprecated != route.Deprecated {
+			t.Errorf("%s %s: deprecated is %v in app.proto but not in openapi.yaml", route.Method, route.Path, route.Deprecated)
+		}
+	}
+}
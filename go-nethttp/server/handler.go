@@ -5,13 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
-	"net"
+	"log/slog"
 	"net/http"
-	"strconv"
+	"strings"
 
+	"github.com/gorilla/mux"
+	"github.com/keith-cullen/microservice/auth"
 	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/metrics"
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/keith-cullen/microservice/ratelimit"
 	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/tracing"
 )
 
 type AppResponse struct {
@@ -19,37 +24,93 @@ type AppResponse struct {
 }
 
 type Handler struct {
-	store       *store.Store
-	rateLimiter *RateLimiter
+	store         *store.Store
+	rateLimiter   *ratelimit.Limiter
+	authenticator *auth.Authenticator
 }
 
 func NewHandler(store *store.Store) (Handler, error) {
-	handler := Handler{}
-	reqPerSec, err := strconv.ParseUint(config.Get(config.ReqPerSecKey), 10, 32)
-	if err != nil {
-		return handler, err
+	cfg := config.Get()
+	handler := Handler{
+		store: store,
+		authenticator: &auth.Authenticator{
+			APIKey: apiKeyIdentity(store),
+			AdminIdentities: func() []string {
+				return config.Get().AdminIdentities
+			},
+		},
+	}
+	if cfg.JWTEnabled() {
+		verifier, err := auth.NewVerifier(auth.VerifierConfig{
+			JWKSFile: cfg.JWKSFile,
+			KeyFiles: cfg.JWTKeyFiles,
+			Secret:   cfg.JWTSecret,
+			Issuer:   cfg.JWTIssuer,
+			Audience: cfg.JWTAudience,
+			Leeway:   cfg.JWTLeeway,
+		})
+		if err != nil {
+			return handler, err
+		}
+		handler.authenticator.Verifier = verifier
 	}
-	burstSize, err := strconv.ParseUint(config.Get(config.BurstSizeKey), 10, 32)
+	rateLimiter, err := ratelimit.Open(cfg, store)
 	if err != nil {
 		return handler, err
 	}
-	rateLimiter := NewRateLimiter(int(reqPerSec), int(burstSize))
-	handler.store = store
 	handler.rateLimiter = rateLimiter
 	return handler, nil
 }
 
-// Send an error response with a JSON-encoded body
-// If the JSON-encoding operation fails, then send a plain text body
-func respondError(w http.ResponseWriter, status int) {
-	msg := http.StatusText(status)
-	resp := &AppResponse{
-		Message: msg,
+// Return a function that looks up the identity given by an API key in the store
+func apiKeyIdentity(store *store.Store) auth.APIKeyFunc {
+	return func(ctx context.Context, key string) (*auth.Identity, error) {
+		k, err := store.AuthenticateAPIKey(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", auth.ErrInvalidAPIKey, err)
+		}
+		return &auth.Identity{
+			Subject: fmt.Sprintf("apikey:%s", k.ID),
+			Names:   []string{k.Owner},
+			Scopes:  k.Scopes,
+		}, nil
 	}
-	if data, err := json.Marshal(&resp); err == nil {
-		msg = string(data)
+}
+
+// Send an error response with a problem details body
+func respondError(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	problem.New(r, status, detail).Write(w)
+}
+
+// Send the error response of an error returned by the store
+func respondStoreError(w http.ResponseWriter, r *http.Request, err error) {
+	problem.FromError(r, err).Write(w)
+}
+
+// Send a bad request response that lists the invalid fields of the request
+func respondInvalid(w http.ResponseWriter, r *http.Request, errs ...problem.FieldError) {
+	problem.Invalid(r, errs...).Write(w)
+}
+
+// Send a response with a JSON-encoded body
+// If the JSON-encoding operation fails, then send an error response
+func respondJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to encode response", "error", err)
+		respondError(w, r, http.StatusInternalServerError, "")
+		return
 	}
-	http.Error(w, msg, status)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// Mark a response as coming from a deprecated endpoint
+// The successor link points to the endpoint that replaces it
+func deprecated(w http.ResponseWriter, successor string) {
+	w.Header().Set("Deprecation", "true")
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
 }
 
 // Send an OK response with a JSON-encoded body
@@ -60,20 +121,30 @@ func respondOk(w http.ResponseWriter, msg string) {
 	}
 	if data, err := json.Marshal(&resp); err == nil {
 		msg = string(data)
+		w.Header().Set("Content-Type", "application/json")
 	}
 	io.WriteString(w, msg)
 }
 
 func (handler Handler) AppDefault(w http.ResponseWriter, r *http.Request) {
-	log.Print("Default()")
-	respondError(w, http.StatusNotFound)
+	slog.DebugContext(r.Context(), "Default")
+	respondError(w, r, http.StatusNotFound, "")
+}
+
+func (handler Handler) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	respondError(w, r, http.StatusMethodNotAllowed, "")
 }
 
 func (handler Handler) AppGet(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Query().Get("name")
-	log.Printf("AppGet(%s)", name)
-	if _, err := handler.store.GetThing(context.Background(), name); err != nil {
-		respondError(w, http.StatusNotFound)
+	slog.DebugContext(r.Context(), "AppGet", "name", name)
+	deprecated(w, "/v1/things")
+	if name == "" {
+		respondInvalid(w, r, requiredName)
+		return
+	}
+	if _, err := handler.store.GetThing(r.Context(), name); err != nil {
+		respondStoreError(w, r, err)
 		return
 	}
 	msg := fmt.Sprintf("Hello, %s", name)
@@ -82,42 +153,147 @@ func (handler Handler) AppGet(w http.ResponseWriter, r *http.Request) {
 
 func (handler Handler) AppSet(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Query().Get("name")
-	log.Printf("AppSet(%s)", name)
-	if err := handler.store.SetThing(context.Background(), name); err != nil {
-		respondError(w, http.StatusInternalServerError)
+	slog.DebugContext(r.Context(), "AppSet", "name", name, "identity", auth.FromContext(r.Context()).String())
+	deprecated(w, "/v1/things")
+	if name == "" {
+		respondInvalid(w, r, requiredName)
+		return
+	}
+	if err := handler.store.SetThing(r.Context(), name); err != nil {
+		respondStoreError(w, r, err)
 		return
 	}
 	msg := fmt.Sprintf("Hello, %s", name)
 	respondOk(w, msg)
 }
 
+// RouteMiddle labels the metrics and names the span of a request after the template of the route that it matched
+func (handler Handler) RouteMiddle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route, err := mux.CurrentRoute(r).GetPathTemplate(); err == nil {
+			metrics.SetRoute(r.Context(), route)
+			tracing.SetRoute(r.Context(), r.Method, route)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (handler Handler) CorsMiddle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		corsOrigin := config.Get(config.CorsOriginKey)
-		w.Header().Set("Access-Control-Allow-Origin", corsOrigin)
+		if corsOrigin := allowedOrigin(config.Get().CorsOrigin, r.Header.Get("Origin")); corsOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", corsOrigin)
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(ratelimit.Headers, ", "))
+		}
+		w.Header().Add("Vary", "Origin")
 		// if this is a preflight options request then write an empty ok response and return
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write(nil)
 			return
 		}
-		log.Print("cors middleware calling next handler")
 		next.ServeHTTP(w, r)
 	})
 }
 
+// Return the value of the Access-Control-Allow-Origin header for a request origin
+// A single configured origin is always returned, otherwise the request origin is returned if it is allowed
+func allowedOrigin(corsOrigins []string, origin string) string {
+	if len(corsOrigins) == 1 {
+		return corsOrigins[0]
+	}
+	for _, corsOrigin := range corsOrigins {
+		if corsOrigin == "*" || corsOrigin == origin {
+			return corsOrigin
+		}
+	}
+	return ""
+}
+
+// RateLimitMiddle rejects requests from clients that have exceeded their rate limit
+// It runs after authentication so that authenticated clients are limited by their identity
 func (handler Handler) RateLimitMiddle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip, _, err := net.SplitHostPort(r.RemoteAddr)
-		if err != nil {
-			log.Printf("unable to determine IP")
+		if handler.rateLimited(w, r, auth.FromContext(r.Context())) {
 			return
 		}
-		if !handler.rateLimiter.Allow(ip) {
-			respondError(w, http.StatusTooManyRequests)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimited takes a request from the rate limit of its client and route
+// and sends an error response if the limit has been exceeded or the client cannot be identified
+func (handler Handler) rateLimited(w http.ResponseWriter, r *http.Request, identity *auth.Identity) bool {
+	cfg := config.Get()
+	ip, err := ratelimit.ClientIP(r, cfg.TrustedProxyPrefixes())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "unable to determine client IP", "error", err)
+		respondError(w, r, http.StatusInternalServerError, "")
+		return true
+	}
+	route, _ := mux.CurrentRoute(r).GetPathTemplate()
+	key, limits := ratelimit.ForRequest(cfg, identity, ip.String(), r.Method, route)
+	result := handler.rateLimiter.Allow(r.Context(), key, limits)
+	result.SetHeaders(w.Header())
+	if !result.Allowed {
+		respondError(w, r, http.StatusTooManyRequests, "")
+		return true
+	}
+	return false
+}
+
+// IdentityMiddle adds the identity given by the client certificate to the request context
+func (handler Handler) IdentityMiddle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if identity := auth.FromRequest(r); identity != nil {
+			r = r.WithContext(auth.NewContext(r.Context(), identity))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WriteMiddle rejects requests that change things from clients that are not allowed to write
+// It runs after authentication so that the identity given by an API key or bearer token is checked,
+// rather than the identity given by the client certificate
+func (handler Handler) WriteMiddle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := auth.FromContext(r.Context())
+		if auth.RequiredScope(r) == auth.ScopeThingsWrite && !identity.Allowed(config.Get().WriteIdentities) {
+			slog.InfoContext(r.Context(), "write denied", "identity", identity.String())
+			if identity == nil {
+				respondError(w, r, http.StatusUnauthorized, "")
+			} else {
+				respondError(w, r, http.StatusForbidden, "")
+			}
 			return
 		}
-		log.Print("rate limit middleware calling next handler")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AuthMiddle authenticates the request with an API key or bearer token, checks the scope needed by the request
+// and adds the identity to the request context
+func (handler Handler) AuthMiddle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+		identity, err := handler.authenticator.Authenticate(r)
+		if err != nil {
+			slog.InfoContext(r.Context(), "authentication failed", "error", err)
+			// failed attempts are limited by client IP so that credentials cannot be guessed at an unlimited rate
+			if handler.rateLimited(w, r, nil) {
+				return
+			}
+			if handler.authenticator.Verifier != nil {
+				w.Header().Set("WWW-Authenticate", auth.Challenge(err, auth.RequiredScope(r)))
+			}
+			respondError(w, r, auth.Status(err), "")
+			return
+		}
+		if identity != nil {
+			r = r.WithContext(auth.NewContext(r.Context(), identity))
+		}
 		next.ServeHTTP(w, r)
 	})
 }
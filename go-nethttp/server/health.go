@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/keith-cullen/microservice/metrics"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/tracing"
+)
+
+const (
+	healthzPath  = "/healthz"
+	readyzPath   = "/readyz"
+	statusPath   = "/v1/status"
+	checkTimeout = 2 * time.Second
+	statusOK     = "ok"
+	statusFail   = "fail"
+)
+
+var (
+	errDraining           = errors.New("server is shutting down")
+	errNoCertificate      = errors.New("certificate not loaded")
+	errCertificateExpired = errors.New("certificate expired")
+)
+
+// Check is the result of one readiness check
+type Check struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BuildInfo struct {
+	GoVersion string `json:"go_version"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified"`
+}
+
+type Readiness struct {
+	Status string           `json:"status"`
+	Checks map[string]Check `json:"checks"`
+}
+
+type Status struct {
+	Readiness
+	Version string    `json:"version"`
+	Started time.Time `json:"started"`
+	Uptime  string    `json:"uptime"`
+	Build   BuildInfo `json:"build"`
+}
+
+// health reports whether the server is alive and ready to serve requests
+type health struct {
+	store        *store.Store
+	started      time.Time
+	tls          atomic.Bool // tls is set if the server needs a certificate to serve requests
+	draining     atomic.Bool
+	certReloader atomic.Pointer[CertReloader]
+}
+
+func newHealth(store *store.Store) *health {
+	return &health{
+		store:   store,
+		started: time.Now(),
+	}
+}
+
+// setCertReloader gives the readiness check the certificate that the server is using
+func (h *health) setCertReloader(certReloader *CertReloader) {
+	h.tls.Store(true)
+	h.certReloader.Store(certReloader)
+}
+
+// drain fails the readiness check from now on so that the server is taken out of service before it stops
+func (h *health) drain() {
+	if !h.draining.Swap(true) {
+		slog.Info("readiness check failing, server is draining")
+	}
+}
+
+// checks runs the readiness checks and returns their results and whether they all passed
+func (h *health) checks(ctx context.Context) (map[string]Check, bool) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	results := map[string]Check{
+		"draining": check(h.checkDraining()),
+		"database": check(h.store.Ping(ctx)),
+	}
+	if h.tls.Load() {
+		results["certificate"] = check(h.checkCertificate())
+	}
+	for _, result := range results {
+		if result.Status != statusOK {
+			return results, false
+		}
+	}
+	return results, true
+}
+
+func (h *health) checkDraining() error {
+	if h.draining.Load() {
+		return errDraining
+	}
+	return nil
+}
+
+func (h *health) checkCertificate() error {
+	certReloader := h.certReloader.Load()
+	if certReloader == nil {
+		return errNoCertificate
+	}
+	if time.Now().After(certReloader.NotAfter()) {
+		return errCertificateExpired
+	}
+	return nil
+}
+
+func check(err error) Check {
+	if err != nil {
+		return Check{Status: statusFail, Error: err.Error()}
+	}
+	return Check{Status: statusOK}
+}
+
+// Healthz reports that the process is alive, it does not depend on anything outside the process
+func (h *health) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, &Check{Status: statusOK})
+}
+
+// Readyz reports whether the server can serve requests
+func (h *health) Readyz(w http.ResponseWriter, r *http.Request) {
+	results, ready := h.checks(r.Context())
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+		slog.WarnContext(r.Context(), "readiness check failed", "checks", results)
+	}
+	writeHealth(w, status, &Readiness{
+		Status: statusText(ready),
+		Checks: results,
+	})
+}
+
+// Status reports the version, uptime and build of the server with the results of the readiness checks
+func (h *health) Status(w http.ResponseWriter, r *http.Request) {
+	results, ready := h.checks(r.Context())
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	version, build := buildInfo()
+	writeHealth(w, status, &Status{
+		Readiness: Readiness{
+			Status: statusText(ready),
+			Checks: results,
+		},
+		Version: version,
+		Started: h.started.UTC(),
+		Uptime:  time.Since(h.started).Round(time.Second).String(),
+		Build:   build,
+	})
+}
+
+func statusText(ok bool) string {
+	if ok {
+		return statusOK
+	}
+	return statusFail
+}
+
+// buildInfo returns the module version and the build settings recorded in the binary
+func buildInfo() (string, BuildInfo) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown", BuildInfo{}
+	}
+	build := BuildInfo{
+		GoVersion: info.GoVersion,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return info.Main.Version, build
+}
+
+// writeHealth sends a JSON-encoded health response that is never cached
+func writeHealth(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// withHealth serves the health endpoints ahead of the API handler
+// so that probes are neither authenticated nor rate limited
+func withHealth(api http.Handler, h *health) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", api)
+	for path, handler := range map[string]http.HandlerFunc{
+		healthzPath: h.Healthz,
+		readyzPath:  h.Readyz,
+		statusPath:  h.Status,
+	} {
+		mux.Handle("GET "+path, withRoute(path, handler))
+	}
+	return mux
+}
+
+// withRoute labels the metrics and names the span of a request to a route outside the API router
+func withRoute(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics.SetRoute(r.Context(), route)
+		tracing.SetRoute(r.Context(), r.Method, route)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/keith-cullen/microservice/metrics"
+)
+
+const (
+	metricsPath = "/metrics"
+)
+
+// withMetrics records the metrics of the requests handled by the API handler
+// and serves the metrics on the API listener unless they have a listener of their own
+func withMetrics(api http.Handler, metricsAddr string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", metrics.Middleware(api))
+	if metricsAddr == "" {
+		mux.Handle("GET "+metricsPath, metrics.Handler())
+	}
+	return mux
+}
+
+// newMetricsServer returns the admin listener that serves the metrics, or nil if they are served by the API listener
+func newMetricsServer(metricsAddr string) *http.Server {
+	if metricsAddr == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET "+metricsPath, metrics.Handler())
+	return &http.Server{
+		Addr:           metricsAddr,
+		Handler:        mux,
+		ReadTimeout:    timeout,
+		WriteTimeout:   timeout,
+		MaxHeaderBytes: maxHeaderBytes,
+	}
+}
+
+// startMetricsServer serves the metrics on the admin listener in the background
+func startMetricsServer(metricsServer *http.Server) {
+	if metricsServer == nil {
+		return
+	}
+	slog.Info("metrics server listening", "addr", metricsServer.Addr)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("metrics server failed", "error", err)
+		}
+	}()
+}
+
+// stopMetricsServer stops the admin listener, a scrape that is in progress has until the request timeout to finish
+func stopMetricsServer(metricsServer *http.Server) error {
+	if metricsServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return metricsServer.Shutdown(ctx)
+}
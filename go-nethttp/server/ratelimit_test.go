@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/keith-cullen/microservice/ratelimit"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	handler := newTestServer(t, map[string]string{"ReqPerSec": "1", "BurstSize": "2", "RouteRateLimits": "POST /v1/set=1/1"})
+	for i := 0; i < 2; i++ {
+		w := serve(t, handler, http.MethodGet, "/v1/things", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: got %d: %s", i, w.Code, w.Body)
+		}
+		if got := w.Header().Get(ratelimit.HeaderLimit); got != "2" {
+			t.Errorf("request %d: got %s %q", i, ratelimit.HeaderLimit, got)
+		}
+		if got := w.Header().Get(ratelimit.HeaderRemaining); got != strconv.Itoa(1-i) {
+			t.Errorf("request %d: got %s %q", i, ratelimit.HeaderRemaining, got)
+		}
+		if got := w.Header().Get(ratelimit.HeaderRetryAfter); got != "" {
+			t.Errorf("request %d: got %s %q", i, ratelimit.HeaderRetryAfter, got)
+		}
+	}
+	w := serve(t, handler, http.MethodGet, "/v1/things", nil)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != problem.ContentType {
+		t.Errorf("got Content-Type %q", contentType)
+	}
+	for name, want := range map[string]string{
+		ratelimit.HeaderLimit:      "2",
+		ratelimit.HeaderRemaining:  "0",
+		ratelimit.HeaderReset:      "2",
+		ratelimit.HeaderRetryAfter: "1",
+	} {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("%s: got %q, want %q", name, got, want)
+		}
+	}
+	// a route limit has a bucket of its own, with its own headers
+	w = serve(t, handler, http.MethodPost, "/v1/set?name=bob", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("route limit: got %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get(ratelimit.HeaderLimit); got != "1" {
+		t.Errorf("route limit: got %s %q", ratelimit.HeaderLimit, got)
+	}
+}
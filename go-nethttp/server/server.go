@@ -2,14 +2,20 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/logging"
+	"github.com/keith-cullen/microservice/ratelimit"
+	"github.com/keith-cullen/microservice/rpc"
 	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/tracing"
 )
 
 const (
@@ -17,38 +23,112 @@ const (
 	maxHeaderBytes = 4096
 )
 
+// ErrShutdownTimeout is returned by Stop if in-flight requests did not finish within the shutdown timeout
+var ErrShutdownTimeout = errors.New("in-flight requests did not finish before the shutdown timeout")
+
 type Server struct {
-	httpServer http.Server
+	httpServer      http.Server
+	router          *mux.Router  // router routes the requests of the API
+	metricsServer   *http.Server // metricsServer is nil if the metrics are served by httpServer
+	health          *health
+	shutdownDelay   time.Duration
+	shutdownTimeout time.Duration
+	rateLimiter     *ratelimit.Limiter
 }
 
 func New(store *store.Store) (*Server, error) {
-	addr := config.Get(config.AddrKey)
+	cfg := config.Get()
+	if cfg.GRPCAddr != "" {
+		// the key is accepted so that both servers can be given the same configuration file
+		slog.Warn("gRPC is not served by this server, GRPCAddr is ignored", "addr", cfg.GRPCAddr)
+	}
 	handler, err := NewHandler(store)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create server: %w", err)
 	}
 	router := mux.NewRouter()
 	router.HandleFunc("/", handler.AppDefault)
-	router.HandleFunc("/v1/get", handler.AppGet).Methods("GET")
-	router.HandleFunc("/v1/set", handler.AppSet).Methods("POST")
+	if cfg.GRPCGateway {
+		if err := registerGateway(router, rpc.NewServices(store)); err != nil {
+			return nil, fmt.Errorf("failed to create server: %w", err)
+		}
+	} else {
+		router.HandleFunc("/v1/get", handler.AppGet).Methods("GET")
+		router.HandleFunc("/v1/set", handler.AppSet).Methods("POST")
+		router.HandleFunc("/v1/things", handler.ThingsList).Methods("GET")
+		router.HandleFunc("/v1/things", handler.ThingsCreate).Methods("POST")
+		router.HandleFunc("/v1/things/{id}", handler.ThingsGet).Methods("GET")
+		router.HandleFunc("/v1/things/{id}", handler.ThingsReplace).Methods("PUT")
+		router.HandleFunc("/v1/things/{id}", handler.ThingsUpdate).Methods("PATCH")
+		router.HandleFunc("/v1/things/{id}", handler.ThingsDelete).Methods("DELETE")
+		router.HandleFunc("/v1/admin/apikeys", handler.APIKeysList).Methods("GET")
+		router.HandleFunc("/v1/admin/apikeys", handler.APIKeysCreate).Methods("POST")
+		router.HandleFunc("/v1/admin/apikeys/{id}", handler.APIKeysRevoke).Methods("DELETE")
+	}
+	// unmatched requests get the same error responses as the routes
+	router.NotFoundHandler = http.HandlerFunc(handler.AppDefault)
+	router.MethodNotAllowedHandler = http.HandlerFunc(handler.MethodNotAllowed)
+	router.Use(handler.RouteMiddle)
 	router.Use(handler.CorsMiddle)
+	router.Use(handler.IdentityMiddle)
+	router.Use(handler.AuthMiddle)
+	router.Use(handler.WriteMiddle)
 	router.Use(handler.RateLimitMiddle)
+	validator, err := newValidator(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+	if validator != nil {
+		router.Use(validator.Middleware)
+	}
+	health := newHealth(store)
+	apiHandler, err := withDocs(router, cfg.APIDocs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
 	return &Server{
 		httpServer: http.Server{
-			Addr:           addr,
-			Handler:        router,
+			Addr:           cfg.Addr,
+			Handler:        tracing.Middleware(logging.Middleware(withMetrics(withHealth(apiHandler, health), cfg.MetricsAddr))),
 			ReadTimeout:    timeout,
 			WriteTimeout:   timeout,
 			MaxHeaderBytes: maxHeaderBytes,
 		},
+		router:          router,
+		metricsServer:   newMetricsServer(cfg.MetricsAddr),
+		health:          health,
+		shutdownDelay:   cfg.ShutdownDelay,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		rateLimiter:     handler.rateLimiter,
 	}, nil
 }
 
 func (server *Server) Start(insecure bool) error {
-	log.Printf("http server listening on %s", server.httpServer.Addr)
+	startMetricsServer(server.metricsServer)
+	slog.Info("http server listening", "addr", server.httpServer.Addr)
 	var err error
 	if !insecure {
-		err = server.httpServer.ListenAndServeTLS(config.Get(config.CertKey), config.Get(config.PrivkeyKey))
+		cfg := config.Get()
+		var certReloader *CertReloader
+		if certReloader, err = NewCertReloader(cfg.Cert, cfg.Privkey); err != nil {
+			return err
+		}
+		defer certReloader.Close()
+		server.health.setCertReloader(certReloader)
+		server.httpServer.TLSConfig = &tls.Config{
+			MinVersion:     cfg.TLSVersion(),
+			CipherSuites:   cfg.TLSCipherSuiteIDs(),
+			GetCertificate: certReloader.GetCertificate,
+			ClientAuth:     cfg.ClientAuthType(),
+		}
+		if cfg.ClientAuthType() != tls.NoClientCert {
+			if server.httpServer.TLSConfig.ClientCAs, err = loadClientCAs(cfg.ClientCA); err != nil {
+				return err
+			}
+			slog.Info("client authentication", "mode", cfg.ClientAuth)
+		}
+		// the certificate and private key are given by the TLS configuration
+		err = server.httpServer.ListenAndServeTLS("", "")
 	} else {
 		err = server.httpServer.ListenAndServe()
 	}
@@ -58,10 +138,31 @@ func (server *Server) Start(insecure bool) error {
 	return err
 }
 
+// Stop fails the readiness check, waits for the shutdown delay so that the server is taken out of service,
+// then stops accepting requests and waits up to the shutdown timeout for the in-flight requests to finish
 func (server *Server) Stop() error {
-	if err := server.httpServer.Shutdown(context.Background()); err != nil {
+	server.health.drain()
+	if server.shutdownDelay > 0 {
+		slog.Info("http server draining", "delay", server.shutdownDelay)
+		time.Sleep(server.shutdownDelay)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), server.shutdownTimeout)
+	defer cancel()
+	err := server.httpServer.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		// the requests that are still in flight are abandoned
+		server.httpServer.Close()
+		err = fmt.Errorf("%w: %s", ErrShutdownTimeout, server.shutdownTimeout)
+	}
+	if metricsErr := stopMetricsServer(server.metricsServer); metricsErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to stop metrics server: %w", metricsErr))
+	}
+	if rateLimiterErr := server.rateLimiter.Close(); rateLimiterErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close rate limiter: %w", rateLimiterErr))
+	}
+	if err != nil {
 		return err
 	}
-	log.Print("http server stopped")
+	slog.Info("http server stopped")
 	return nil
 }
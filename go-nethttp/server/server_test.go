@@ -0,0 +1,91 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/store"
+)
+
+// newTestServer returns the handler of a server on a new in-memory SQLite database with the schema applied
+// The configuration is given in the environment, on top of generous rate limits
+func newTestServer(t *testing.T, env map[string]string) http.Handler {
+	t.Helper()
+	return openTestServer(t, env).httpServer.Handler
+}
+
+// openTestServer returns a server like newTestServer, for the tests that need more than its handler
+func openTestServer(t *testing.T, env map[string]string) *Server {
+	t.Helper()
+	dsn := "file:" + strings.ReplaceAll(t.Name(), "/", "_") + "?mode=memory&cache=shared&_fk=1"
+	t.Setenv(config.EnvName("DatabaseDSN"), dsn)
+	t.Setenv(config.EnvName("ReqPerSec"), "1000")
+	t.Setenv(config.EnvName("BurstSize"), "1000")
+	for key, value := range env {
+		t.Setenv(config.EnvName(key), value)
+	}
+	if err := config.Open(""); err != nil {
+		t.Fatal(err)
+	}
+	// the in-memory database only lives while one of its connections is open
+	keep, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := keep.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { keep.Close() })
+	if err := store.MigrateUp(context.Background(), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	s, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	server, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.rateLimiter.Close() })
+	return server
+}
+
+// serve sends a request with an optional JSON body to a handler and returns the response
+func serve(t *testing.T, handler http.Handler, method, target string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	r := httptest.NewRequest(method, target, reader)
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+// decode decodes the JSON body of a response
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("failed to decode response: %v: %s", err, w.Body)
+	}
+	return v
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/keith-cullen/microservice/problem"
+	"github.com/keith-cullen/microservice/store"
+	"github.com/keith-cullen/microservice/store/ent"
+)
+
+const (
+	maxBodyBytes = 1 << 20
+)
+
+// The field errors of the requests that are shared by the handlers
+var (
+	invalidID = problem.FieldError{Field: "id", Detail: "must be a UUID"}
+	emptyName = problem.FieldError{Field: "name", Detail: "must not be empty"}
+	// requiredName is the error of the name query parameter of the deprecated endpoints
+	requiredName = problem.FieldError{Field: "name", Detail: "is required"}
+)
+
+type Thing struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Attributes  map[string]any `json:"attributes"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type ThingInput struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Attributes  map[string]any `json:"attributes,omitempty"`
+}
+
+type ThingPatch struct {
+	Name        *string        `json:"name,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Attributes  map[string]any `json:"attributes,omitempty"`
+}
+
+type ThingList struct {
+	Things     []Thing `json:"things"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+func newThing(t *ent.Thing) Thing {
+	attributes := t.Attributes
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return Thing{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Attributes:  attributes,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// Parse the ID of a thing or API key from the request path
+func pathID(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(mux.Vars(r)["id"])
+}
+
+// Decode a JSON request body into v
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	return json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(v)
+}
+
+// Send the error response of a request body that cannot be decoded
+func respondBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		respondInvalid(w, r, problem.FieldError{Field: typeErr.Field, Detail: fmt.Sprintf("must not be a %s", typeErr.Value)})
+	case errors.As(err, &maxBytesErr):
+		respondError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("the request body is larger than %d bytes", maxBytesErr.Limit))
+	default:
+		respondError(w, r, http.StatusBadRequest, "the request body is not a valid JSON object")
+	}
+}
+
+func (handler Handler) ThingsList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := store.ListOptions{
+		Prefix:   query.Get("prefix"),
+		Contains: query.Get("contains"),
+		Sort:     query.Get("sort"),
+		Cursor:   query.Get("cursor"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if opts.Limit, err = strconv.Atoi(limit); err != nil || opts.Limit < 1 {
+			respondInvalid(w, r, problem.FieldError{Field: "limit", Detail: "must be a positive integer"})
+			return
+		}
+	}
+	slog.DebugContext(r.Context(), "ThingsList", "options", opts)
+	page, err := handler.store.ListThings(r.Context(), opts)
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	resp := ThingList{Things: make([]Thing, 0, len(page.Things))}
+	for _, t := range page.Things {
+		resp.Things = append(resp.Things, newThing(t))
+	}
+	if page.NextCursor != "" {
+		resp.NextCursor = page.NextCursor
+		w.Header().Set("Link", nextLink(r.URL, page.NextCursor))
+	}
+	respondJSON(w, r, http.StatusOK, resp)
+}
+
+// Build a Link header value that points to the next page of a list
+func nextLink(u *url.URL, cursor string) string {
+	query := u.Query()
+	query.Set("cursor", cursor)
+	next := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf("<%s>; rel=\"next\"", next.String())
+}
+
+func (handler Handler) ThingsCreate(w http.ResponseWriter, r *http.Request) {
+	var input ThingInput
+	if err := decodeBody(w, r, &input); err != nil {
+		respondBodyError(w, r, err)
+		return
+	}
+	if input.Name == "" {
+		respondInvalid(w, r, emptyName)
+		return
+	}
+	slog.DebugContext(r.Context(), "ThingsCreate", "name", input.Name)
+	t, err := handler.store.CreateThing(r.Context(), store.ThingFields(input))
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/v1/things/%s", t.ID))
+	respondJSON(w, r, http.StatusCreated, newThing(t))
+}
+
+func (handler Handler) ThingsGet(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respondInvalid(w, r, invalidID)
+		return
+	}
+	slog.DebugContext(r.Context(), "ThingsGet", "id", id)
+	t, err := handler.store.GetThingByID(r.Context(), id)
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	respondJSON(w, r, http.StatusOK, newThing(t))
+}
+
+func (handler Handler) ThingsReplace(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respondInvalid(w, r, invalidID)
+		return
+	}
+	var input ThingInput
+	if err := decodeBody(w, r, &input); err != nil {
+		respondBodyError(w, r, err)
+		return
+	}
+	if input.Name == "" {
+		respondInvalid(w, r, emptyName)
+		return
+	}
+	slog.DebugContext(r.Context(), "ThingsReplace", "id", id, "name", input.Name)
+	t, err := handler.store.ReplaceThing(r.Context(), id, store.ThingFields(input))
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	respondJSON(w, r, http.StatusOK, newThing(t))
+}
+
+func (handler Handler) ThingsUpdate(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respondInvalid(w, r, invalidID)
+		return
+	}
+	var patch ThingPatch
+	if err := decodeBody(w, r, &patch); err != nil {
+		respondBodyError(w, r, err)
+		return
+	}
+	if patch.Name != nil && *patch.Name == "" {
+		respondInvalid(w, r, emptyName)
+		return
+	}
+	slog.DebugContext(r.Context(), "ThingsUpdate", "id", id)
+	t, err := handler.store.PatchThing(r.Context(), id, store.ThingPatch(patch))
+	if err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	respondJSON(w, r, http.StatusOK, newThing(t))
+}
+
+func (handler Handler) ThingsDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		respondInvalid(w, r, invalidID)
+		return
+	}
+	slog.DebugContext(r.Context(), "ThingsDelete", "id", id)
+	if err := handler.store.DeleteThing(r.Context(), id); err != nil {
+		respondStoreError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
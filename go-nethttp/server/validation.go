@@ -0,0 +1,21 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/keith-cullen/microservice/api"
+	"github.com/keith-cullen/microservice/config"
+	"github.com/keith-cullen/microservice/validation"
+)
+
+// newValidator creates the validator of the OpenAPI spec that is selected by the configuration, nil if validation is off
+func newValidator(cfg *config.Config) (*validation.Validator, error) {
+	if cfg.OpenAPIValidation == "off" {
+		return nil, nil
+	}
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	return validation.New(spec, cfg.OpenAPIValidation == "strict")
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// TestStrictValidation checks that the requests and responses of each endpoint match the OpenAPI spec,
+// since strict validation replaces the responses that do not with server errors
+func TestStrictValidation(t *testing.T) {
+	handler := newTestServer(t, map[string]string{"OpenAPIValidation": "strict"})
+	w := serve(t, handler, http.MethodPost, "/v1/things", json.RawMessage(`{"name":"bob","attributes":{"colour":"red"}}`))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: got %d: %s", w.Code, w.Body)
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	path := "/v1/things/" + created.ID
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"get", http.MethodGet, path, "", http.StatusOK},
+		{"list", http.MethodGet, "/v1/things?limit=1&sort=-name", "", http.StatusOK},
+		{"replace", http.MethodPut, path, `{"name":"alice","description":"a thing"}`, http.StatusOK},
+		{"patch", http.MethodPatch, path, `{"attributes":{"size":3}}`, http.StatusOK},
+		{"set", http.MethodPost, "/v1/set?name=carol", "", http.StatusOK},
+		{"get by name", http.MethodGet, "/v1/get?name=carol", "", http.StatusOK},
+		{"create invalid", http.MethodPost, "/v1/things", `{"name":""}`, http.StatusBadRequest},
+		{"invalid limit", http.MethodGet, "/v1/things?limit=0", "", http.StatusBadRequest},
+		{"duplicate", http.MethodPost, "/v1/things", `{"name":"alice"}`, http.StatusConflict},
+		{"delete", http.MethodDelete, path, "", http.StatusNoContent},
+		{"get deleted", http.MethodGet, path, "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var body any
+		if tt.body != "" {
+			body = json.RawMessage(tt.body)
+		}
+		w := serve(t, handler, tt.method, tt.target, body)
+		if w.Code != tt.status {
+			t.Errorf("%s: got %d: %s", tt.name, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/keith-cullen/microservice/store/ent/apikey"
+)
+
+const (
+	apiKeyPrefix      = "msk_"          // apiKeyPrefix makes API keys easy to recognise, e.g. by secret scanners
+	apiKeyCacheTTL    = 5 * time.Second // apiKeyCacheTTL bounds how long a revoked key can still be used by another replica
+	apiKeyCacheSize   = 10000           // apiKeyCacheSize bounds the number of cached lookups, including those of unknown keys
+	apiKeyUsedEvery   = 1 * time.Minute // apiKeyUsedEvery is the resolution of the last used time
+	apiKeySecretBytes = 32
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrInvalidScope  = errors.New("invalid scope")
+)
+
+// APIKeyScopes are the scopes that can be granted to an API key
+var APIKeyScopes = []string{"things:read", "things:write", "apikeys:admin"}
+
+// APIKeyFields holds the fields of an API key that are set when it is created
+type APIKeyFields struct {
+	Owner     string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// apiKeyCacheEntry is a cached result of an API key lookup, key is nil if the API key is not valid
+type apiKeyCacheEntry struct {
+	key       *ent.APIKey
+	fetchedAt time.Time
+}
+
+// apiKeyCache caches API key lookups by hash so that each request does not need a database query
+type apiKeyCache struct {
+	mu      sync.Mutex
+	entries map[string]apiKeyCacheEntry
+	revoked uint64 // revoked counts the revocations so that a lookup that raced with one is not cached
+}
+
+func (cache *apiKeyCache) get(hash string) (apiKeyCacheEntry, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	entry, ok := cache.entries[hash]
+	if !ok || time.Since(entry.fetchedAt) > apiKeyCacheTTL {
+		return apiKeyCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// revocations returns the number of revocations, which is taken before a lookup and given to put
+func (cache *apiKeyCache) revocations() uint64 {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.revoked
+}
+
+// put caches a lookup unless a key has been revoked since the lookup started, since it may have read the key before its revocation
+func (cache *apiKeyCache) put(hash string, key *ent.APIKey, revocations uint64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.revoked != revocations {
+		return
+	}
+	if cache.entries == nil || len(cache.entries) >= apiKeyCacheSize {
+		cache.entries = map[string]apiKeyCacheEntry{}
+	}
+	cache.entries[hash] = apiKeyCacheEntry{key: key, fetchedAt: time.Now()}
+}
+
+// remove drops the cached lookup of a key so that its revocation takes effect immediately in this process
+func (cache *apiKeyCache) remove(id uuid.UUID) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.revoked++
+	for hash, entry := range cache.entries {
+		if entry.key != nil && entry.key.ID == id {
+			delete(cache.entries, hash)
+		}
+	}
+}
+
+// hashAPIKey returns the hash under which an API key is stored
+// API keys are random so a fast hash is enough
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey creates an API key and returns it with its secret
+// The secret cannot be recovered later
+func (store *Store) CreateAPIKey(ctx context.Context, fields APIKeyFields) (*ent.APIKey, string, error) {
+	for _, scope := range fields.Scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return nil, "", fmt.Errorf("failed to create API key: %w: %q", ErrInvalidScope, scope)
+		}
+	}
+	b := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	k, err := store.Client.APIKey.
+		Create().
+		SetHash(hashAPIKey(secret)).
+		SetOwner(fields.Owner).
+		SetScopes(fields.Scopes).
+		SetNillableExpiresAt(fields.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to create API key: %w", err)
+		slog.ErrorContext(ctx, err.Error())
+		return nil, "", err
+	}
+	slog.InfoContext(ctx, "created API key", "id", k.ID, "owner", k.Owner)
+	return k, secret, nil
+}
+
+// ListAPIKeys returns all API keys, including revoked and expired keys, oldest first
+func (store *Store) ListAPIKeys(ctx context.Context) ([]*ent.APIKey, error) {
+	keys, err := store.Client.APIKey.
+		Query().
+		Order(ent.Asc(apikey.FieldCreatedAt), ent.Asc(apikey.FieldID)).
+		All(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to list API keys: %w", err)
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes the API key with the given ID
+// Revoking a key that has already been revoked has no effect
+func (store *Store) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	k, err := store.Client.APIKey.Get(ctx, id)
+	if err == nil && k.RevokedAt == nil {
+		err = store.Client.APIKey.UpdateOneID(id).SetRevokedAt(time.Now()).Exec(ctx)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to revoke API key: %w", err)
+		slog.WarnContext(ctx, err.Error())
+		return err
+	}
+	store.apiKeys.remove(id)
+	slog.InfoContext(ctx, "revoked API key", "id", id)
+	return nil
+}
+
+// AuthenticateAPIKey returns the API key with the given secret if it is valid
+// Lookups are cached for a few seconds, so a key that is revoked by another replica can still be used briefly
+func (store *Store) AuthenticateAPIKey(ctx context.Context, secret string) (*ent.APIKey, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	hash := hashAPIKey(secret)
+	entry, ok := store.apiKeys.get(hash)
+	if !ok {
+		revocations := store.apiKeys.revocations()
+		k, err := store.Client.APIKey.Query().Where(apikey.Hash(hash)).Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to find API key: %w", err)
+		}
+		if k != nil && (k.LastUsedAt == nil || time.Since(*k.LastUsedAt) > apiKeyUsedEvery) {
+			now := time.Now()
+			if err := store.Client.APIKey.UpdateOneID(k.ID).SetLastUsedAt(now).Exec(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to update API key last used time", "error", err)
+			} else {
+				k.LastUsedAt = &now
+			}
+		}
+		store.apiKeys.put(hash, k, revocations)
+		entry.key = k
+	}
+	k := entry.key
+	if k == nil || k.RevokedAt != nil || (k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+	return k, nil
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"entgo.io/ent/dialect"
+	"github.com/keith-cullen/microservice/config"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// databaseDriver pairs a database/sql driver with the matching ent dialect
+type databaseDriver struct {
+	sqlDriver string
+	dialect   string
+}
+
+// databaseDrivers maps the values of config.DatabaseDrivers to database drivers
+var databaseDrivers = map[string]databaseDriver{
+	"sqlite3":  {sqlDriver: "sqlite3", dialect: dialect.SQLite},
+	"postgres": {sqlDriver: "pgx", dialect: dialect.Postgres},
+	"mysql":    {sqlDriver: "mysql", dialect: dialect.MySQL},
+}
+
+// openDB opens the configured database and returns it with its ent dialect
+func openDB() (*sql.DB, string, error) {
+	cfg := config.Get()
+	driver, ok := databaseDrivers[cfg.DatabaseDriver]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported database driver: %q", cfg.DatabaseDriver)
+	}
+	db, err := sql.Open(driver.sqlDriver, cfg.DSN())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(cfg.DatabaseMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DatabaseMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DatabaseConnMaxLifetime)
+	if driver.dialect == dialect.SQLite {
+		// the write-ahead log lets readers run concurrently with a writer
+		// it is a persistent property of the database file so it applies to every connection
+		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+			db.Close()
+			return nil, "", fmt.Errorf("failed to enable write-ahead log: %w", err)
+		}
+	}
+	slog.Info("database driver", "driver", cfg.DatabaseDriver)
+	return db, driver.dialect, nil
+}
@@ -12,6 +12,8 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/keith-cullen/microservice/store/ent/apikey"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
 	"github.com/keith-cullen/microservice/store/ent/thing"
 )
 
@@ -73,7 +75,9 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			thing.Table: thing.ValidColumn,
+			apikey.Table:    apikey.ValidColumn,
+			ratelimit.Table: ratelimit.ValidColumn,
+			thing.Table:     thing.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
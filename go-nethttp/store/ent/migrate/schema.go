@@ -8,19 +8,75 @@ import (
 )
 
 var (
+	// APIKeysColumns holds the columns for the "api_keys" table.
+	APIKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "hash", Type: field.TypeString},
+		{Name: "owner", Type: field.TypeString},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+	}
+	// APIKeysTable holds the schema information for the "api_keys" table.
+	APIKeysTable = &schema.Table{
+		Name:       "api_keys",
+		Columns:    APIKeysColumns,
+		PrimaryKey: []*schema.Column{APIKeysColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "apikey_hash",
+				Unique:  true,
+				Columns: []*schema.Column{APIKeysColumns[3]},
+			},
+		},
+	}
+	// RateLimitsColumns holds the columns for the "rate_limits" table.
+	RateLimitsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Size: 255},
+		{Name: "tat", Type: field.TypeInt64},
+	}
+	// RateLimitsTable holds the schema information for the "rate_limits" table.
+	RateLimitsTable = &schema.Table{
+		Name:       "rate_limits",
+		Columns:    RateLimitsColumns,
+		PrimaryKey: []*schema.Column{RateLimitsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "ratelimit_tat",
+				Unique:  false,
+				Columns: []*schema.Column{RateLimitsColumns[1]},
+			},
+		},
+	}
 	// ThingsColumns holds the columns for the "things" table.
 	ThingsColumns = []*schema.Column{
-		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "name", Type: field.TypeString, Default: "unknown"},
+		{Name: "description", Type: field.TypeString, Default: ""},
+		{Name: "attributes", Type: field.TypeJSON, Nullable: true},
 	}
 	// ThingsTable holds the schema information for the "things" table.
 	ThingsTable = &schema.Table{
 		Name:       "things",
 		Columns:    ThingsColumns,
 		PrimaryKey: []*schema.Column{ThingsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "thing_name",
+				Unique:  true,
+				Columns: []*schema.Column{ThingsColumns[3]},
+			},
+		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		APIKeysTable,
+		RateLimitsTable,
 		ThingsTable,
 	}
 )
@@ -0,0 +1,123 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ratelimit
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/keith-cullen/microservice/store/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldContainsFold(FieldID, id))
+}
+
+// Tat applies equality check predicate on the "tat" field. It's identical to TatEQ.
+func Tat(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldEQ(FieldTat, v))
+}
+
+// TatEQ applies the EQ predicate on the "tat" field.
+func TatEQ(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldEQ(FieldTat, v))
+}
+
+// TatNEQ applies the NEQ predicate on the "tat" field.
+func TatNEQ(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldNEQ(FieldTat, v))
+}
+
+// TatIn applies the In predicate on the "tat" field.
+func TatIn(vs ...int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldIn(FieldTat, vs...))
+}
+
+// TatNotIn applies the NotIn predicate on the "tat" field.
+func TatNotIn(vs ...int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldNotIn(FieldTat, vs...))
+}
+
+// TatGT applies the GT predicate on the "tat" field.
+func TatGT(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldGT(FieldTat, v))
+}
+
+// TatGTE applies the GTE predicate on the "tat" field.
+func TatGTE(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldGTE(FieldTat, v))
+}
+
+// TatLT applies the LT predicate on the "tat" field.
+func TatLT(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldLT(FieldTat, v))
+}
+
+// TatLTE applies the LTE predicate on the "tat" field.
+func TatLTE(v int64) predicate.RateLimit {
+	return predicate.RateLimit(sql.FieldLTE(FieldTat, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.RateLimit) predicate.RateLimit {
+	return predicate.RateLimit(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.RateLimit) predicate.RateLimit {
+	return predicate.RateLimit(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.RateLimit) predicate.RateLimit {
+	return predicate.RateLimit(sql.NotPredicates(p))
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/keith-cullen/microservice/store/ent/predicate"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
+)
+
+// RateLimitDelete is the builder for deleting a RateLimit entity.
+type RateLimitDelete struct {
+	config
+	hooks    []Hook
+	mutation *RateLimitMutation
+}
+
+// Where appends a list predicates to the RateLimitDelete builder.
+func (rld *RateLimitDelete) Where(ps ...predicate.RateLimit) *RateLimitDelete {
+	rld.mutation.Where(ps...)
+	return rld
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (rld *RateLimitDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, rld.sqlExec, rld.mutation, rld.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rld *RateLimitDelete) ExecX(ctx context.Context) int {
+	n, err := rld.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (rld *RateLimitDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(ratelimit.Table, sqlgraph.NewFieldSpec(ratelimit.FieldID, field.TypeString))
+	if ps := rld.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, rld.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	rld.mutation.done = true
+	return affected, err
+}
+
+// RateLimitDeleteOne is the builder for deleting a single RateLimit entity.
+type RateLimitDeleteOne struct {
+	rld *RateLimitDelete
+}
+
+// Where appends a list predicates to the RateLimitDelete builder.
+func (rldo *RateLimitDeleteOne) Where(ps ...predicate.RateLimit) *RateLimitDeleteOne {
+	rldo.rld.mutation.Where(ps...)
+	return rldo
+}
+
+// Exec executes the deletion query.
+func (rldo *RateLimitDeleteOne) Exec(ctx context.Context) error {
+	n, err := rldo.rld.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{ratelimit.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rldo *RateLimitDeleteOne) ExecX(ctx context.Context) {
+	if err := rldo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
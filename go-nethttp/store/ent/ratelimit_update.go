@@ -0,0 +1,229 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/keith-cullen/microservice/store/ent/predicate"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
+)
+
+// RateLimitUpdate is the builder for updating RateLimit entities.
+type RateLimitUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RateLimitMutation
+}
+
+// Where appends a list predicates to the RateLimitUpdate builder.
+func (rlu *RateLimitUpdate) Where(ps ...predicate.RateLimit) *RateLimitUpdate {
+	rlu.mutation.Where(ps...)
+	return rlu
+}
+
+// SetTat sets the "tat" field.
+func (rlu *RateLimitUpdate) SetTat(i int64) *RateLimitUpdate {
+	rlu.mutation.ResetTat()
+	rlu.mutation.SetTat(i)
+	return rlu
+}
+
+// SetNillableTat sets the "tat" field if the given value is not nil.
+func (rlu *RateLimitUpdate) SetNillableTat(i *int64) *RateLimitUpdate {
+	if i != nil {
+		rlu.SetTat(*i)
+	}
+	return rlu
+}
+
+// AddTat adds i to the "tat" field.
+func (rlu *RateLimitUpdate) AddTat(i int64) *RateLimitUpdate {
+	rlu.mutation.AddTat(i)
+	return rlu
+}
+
+// Mutation returns the RateLimitMutation object of the builder.
+func (rlu *RateLimitUpdate) Mutation() *RateLimitMutation {
+	return rlu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (rlu *RateLimitUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, rlu.sqlSave, rlu.mutation, rlu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rlu *RateLimitUpdate) SaveX(ctx context.Context) int {
+	affected, err := rlu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (rlu *RateLimitUpdate) Exec(ctx context.Context) error {
+	_, err := rlu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rlu *RateLimitUpdate) ExecX(ctx context.Context) {
+	if err := rlu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (rlu *RateLimitUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(ratelimit.Table, ratelimit.Columns, sqlgraph.NewFieldSpec(ratelimit.FieldID, field.TypeString))
+	if ps := rlu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rlu.mutation.Tat(); ok {
+		_spec.SetField(ratelimit.FieldTat, field.TypeInt64, value)
+	}
+	if value, ok := rlu.mutation.AddedTat(); ok {
+		_spec.AddField(ratelimit.FieldTat, field.TypeInt64, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, rlu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ratelimit.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	rlu.mutation.done = true
+	return n, nil
+}
+
+// RateLimitUpdateOne is the builder for updating a single RateLimit entity.
+type RateLimitUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RateLimitMutation
+}
+
+// SetTat sets the "tat" field.
+func (rluo *RateLimitUpdateOne) SetTat(i int64) *RateLimitUpdateOne {
+	rluo.mutation.ResetTat()
+	rluo.mutation.SetTat(i)
+	return rluo
+}
+
+// SetNillableTat sets the "tat" field if the given value is not nil.
+func (rluo *RateLimitUpdateOne) SetNillableTat(i *int64) *RateLimitUpdateOne {
+	if i != nil {
+		rluo.SetTat(*i)
+	}
+	return rluo
+}
+
+// AddTat adds i to the "tat" field.
+func (rluo *RateLimitUpdateOne) AddTat(i int64) *RateLimitUpdateOne {
+	rluo.mutation.AddTat(i)
+	return rluo
+}
+
+// Mutation returns the RateLimitMutation object of the builder.
+func (rluo *RateLimitUpdateOne) Mutation() *RateLimitMutation {
+	return rluo.mutation
+}
+
+// Where appends a list predicates to the RateLimitUpdate builder.
+func (rluo *RateLimitUpdateOne) Where(ps ...predicate.RateLimit) *RateLimitUpdateOne {
+	rluo.mutation.Where(ps...)
+	return rluo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (rluo *RateLimitUpdateOne) Select(field string, fields ...string) *RateLimitUpdateOne {
+	rluo.fields = append([]string{field}, fields...)
+	return rluo
+}
+
+// Save executes the query and returns the updated RateLimit entity.
+func (rluo *RateLimitUpdateOne) Save(ctx context.Context) (*RateLimit, error) {
+	return withHooks(ctx, rluo.sqlSave, rluo.mutation, rluo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rluo *RateLimitUpdateOne) SaveX(ctx context.Context) *RateLimit {
+	node, err := rluo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (rluo *RateLimitUpdateOne) Exec(ctx context.Context) error {
+	_, err := rluo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rluo *RateLimitUpdateOne) ExecX(ctx context.Context) {
+	if err := rluo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (rluo *RateLimitUpdateOne) sqlSave(ctx context.Context) (_node *RateLimit, err error) {
+	_spec := sqlgraph.NewUpdateSpec(ratelimit.Table, ratelimit.Columns, sqlgraph.NewFieldSpec(ratelimit.FieldID, field.TypeString))
+	id, ok := rluo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "RateLimit.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := rluo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, ratelimit.FieldID)
+		for _, f := range fields {
+			if !ratelimit.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != ratelimit.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := rluo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rluo.mutation.Tat(); ok {
+		_spec.SetField(ratelimit.FieldTat, field.TypeInt64, value)
+	}
+	if value, ok := rluo.mutation.AddedTat(); ok {
+		_spec.AddField(ratelimit.FieldTat, field.TypeInt64, value)
+	}
+	_node = &RateLimit{config: rluo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, rluo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ratelimit.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	rluo.mutation.done = true
+	return _node, nil
+}
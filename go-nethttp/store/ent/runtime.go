@@ -3,6 +3,11 @@
 package ent
 
 import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/store/ent/apikey"
+	"github.com/keith-cullen/microservice/store/ent/ratelimit"
 	"github.com/keith-cullen/microservice/store/ent/schema"
 	"github.com/keith-cullen/microservice/store/ent/thing"
 )
@@ -11,10 +16,74 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apikeyMixin := schema.APIKey{}.Mixin()
+	apikeyMixinFields0 := apikeyMixin[0].Fields()
+	_ = apikeyMixinFields0
+	apikeyFields := schema.APIKey{}.Fields()
+	_ = apikeyFields
+	// apikeyDescCreatedAt is the schema descriptor for created_at field.
+	apikeyDescCreatedAt := apikeyMixinFields0[0].Descriptor()
+	// apikey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apikey.DefaultCreatedAt = apikeyDescCreatedAt.Default.(func() time.Time)
+	// apikeyDescUpdatedAt is the schema descriptor for updated_at field.
+	apikeyDescUpdatedAt := apikeyMixinFields0[1].Descriptor()
+	// apikey.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	apikey.DefaultUpdatedAt = apikeyDescUpdatedAt.Default.(func() time.Time)
+	// apikey.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	apikey.UpdateDefaultUpdatedAt = apikeyDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// apikeyDescOwner is the schema descriptor for owner field.
+	apikeyDescOwner := apikeyFields[2].Descriptor()
+	// apikey.OwnerValidator is a validator for the "owner" field. It is called by the builders before save.
+	apikey.OwnerValidator = apikeyDescOwner.Validators[0].(func(string) error)
+	// apikeyDescID is the schema descriptor for id field.
+	apikeyDescID := apikeyFields[0].Descriptor()
+	// apikey.DefaultID holds the default value on creation for the id field.
+	apikey.DefaultID = apikeyDescID.Default.(func() uuid.UUID)
+	ratelimitFields := schema.RateLimit{}.Fields()
+	_ = ratelimitFields
+	// ratelimitDescID is the schema descriptor for id field.
+	ratelimitDescID := ratelimitFields[0].Descriptor()
+	// ratelimit.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	ratelimit.IDValidator = func() func(string) error {
+		validators := ratelimitDescID.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(id string) error {
+			for _, fn := range fns {
+				if err := fn(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	thingMixin := schema.Thing{}.Mixin()
+	thingMixinFields0 := thingMixin[0].Fields()
+	_ = thingMixinFields0
 	thingFields := schema.Thing{}.Fields()
 	_ = thingFields
+	// thingDescCreatedAt is the schema descriptor for created_at field.
+	thingDescCreatedAt := thingMixinFields0[0].Descriptor()
+	// thing.DefaultCreatedAt holds the default value on creation for the created_at field.
+	thing.DefaultCreatedAt = thingDescCreatedAt.Default.(func() time.Time)
+	// thingDescUpdatedAt is the schema descriptor for updated_at field.
+	thingDescUpdatedAt := thingMixinFields0[1].Descriptor()
+	// thing.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	thing.DefaultUpdatedAt = thingDescUpdatedAt.Default.(func() time.Time)
+	// thing.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	thing.UpdateDefaultUpdatedAt = thingDescUpdatedAt.UpdateDefault.(func() time.Time)
 	// thingDescName is the schema descriptor for name field.
-	thingDescName := thingFields[0].Descriptor()
+	thingDescName := thingFields[1].Descriptor()
 	// thing.DefaultName holds the default value on creation for the name field.
 	thing.DefaultName = thingDescName.Default.(string)
+	// thingDescDescription is the schema descriptor for description field.
+	thingDescDescription := thingFields[2].Descriptor()
+	// thing.DefaultDescription holds the default value on creation for the description field.
+	thing.DefaultDescription = thingDescDescription.Default.(string)
+	// thingDescID is the schema descriptor for id field.
+	thingDescID := thingFields[0].Descriptor()
+	// thing.DefaultID holds the default value on creation for the id field.
+	thing.DefaultID = thingDescID.Default.(func() uuid.UUID)
 }
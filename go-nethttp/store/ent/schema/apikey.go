@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// APIKey holds the schema definition for the APIKey entity.
+// Only a hash of the secret key is stored.
+type APIKey struct {
+	ent.Schema
+}
+
+// Mixin of the APIKey.
+func (APIKey) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
+// Fields of the APIKey.
+func (APIKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("hash").
+			Immutable().
+			Sensitive(),
+		field.String("owner").
+			NotEmpty(),
+		field.JSON("scopes", []string{}),
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the APIKey.
+func (APIKey) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the APIKey.
+func (APIKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("hash").
+			Unique(),
+	}
+}
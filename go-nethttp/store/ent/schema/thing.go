@@ -3,6 +3,8 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
 )
 
 // Thing holds the schema definition for the Thing entity.
@@ -10,11 +12,25 @@ type Thing struct {
 	ent.Schema
 }
 
+// Mixin of the Thing.
+func (Thing) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
 // Fields of the Thing.
 func (Thing) Fields() []ent.Field {
 	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
 		field.String("name").
 			Default("unknown"),
+		field.String("description").
+			Default(""),
+		field.JSON("attributes", map[string]any{}).
+			Optional(),
 	}
 }
 
@@ -22,3 +38,11 @@ func (Thing) Fields() []ent.Field {
 func (Thing) Edges() []ent.Edge {
 	return nil
 }
+
+// Indexes of the Thing.
+func (Thing) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").
+			Unique(),
+	}
+}
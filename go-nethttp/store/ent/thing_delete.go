@@ -40,7 +40,7 @@ func (td *ThingDelete) ExecX(ctx context.Context) int {
 }
 
 func (td *ThingDelete) sqlExec(ctx context.Context) (int, error) {
-	_spec := sqlgraph.NewDeleteSpec(thing.Table, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeInt))
+	_spec := sqlgraph.NewDeleteSpec(thing.Table, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeUUID))
 	if ps := td.mutation.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
 			for i := range ps {
@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
 	"github.com/keith-cullen/microservice/store/ent/predicate"
 	"github.com/keith-cullen/microservice/store/ent/thing"
 )
@@ -82,8 +83,8 @@ func (tq *ThingQuery) FirstX(ctx context.Context) *Thing {
 
 // FirstID returns the first Thing ID from the query.
 // Returns a *NotFoundError when no Thing ID was found.
-func (tq *ThingQuery) FirstID(ctx context.Context) (id int, err error) {
-	var ids []int
+func (tq *ThingQuery) FirstID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
 	if ids, err = tq.Limit(1).IDs(setContextOp(ctx, tq.ctx, ent.OpQueryFirstID)); err != nil {
 		return
 	}
@@ -95,7 +96,7 @@ func (tq *ThingQuery) FirstID(ctx context.Context) (id int, err error) {
 }
 
 // FirstIDX is like FirstID, but panics if an error occurs.
-func (tq *ThingQuery) FirstIDX(ctx context.Context) int {
+func (tq *ThingQuery) FirstIDX(ctx context.Context) uuid.UUID {
 	id, err := tq.FirstID(ctx)
 	if err != nil && !IsNotFound(err) {
 		panic(err)
@@ -133,8 +134,8 @@ func (tq *ThingQuery) OnlyX(ctx context.Context) *Thing {
 // OnlyID is like Only, but returns the only Thing ID in the query.
 // Returns a *NotSingularError when more than one Thing ID is found.
 // Returns a *NotFoundError when no entities are found.
-func (tq *ThingQuery) OnlyID(ctx context.Context) (id int, err error) {
-	var ids []int
+func (tq *ThingQuery) OnlyID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
 	if ids, err = tq.Limit(2).IDs(setContextOp(ctx, tq.ctx, ent.OpQueryOnlyID)); err != nil {
 		return
 	}
@@ -150,7 +151,7 @@ func (tq *ThingQuery) OnlyID(ctx context.Context) (id int, err error) {
 }
 
 // OnlyIDX is like OnlyID, but panics if an error occurs.
-func (tq *ThingQuery) OnlyIDX(ctx context.Context) int {
+func (tq *ThingQuery) OnlyIDX(ctx context.Context) uuid.UUID {
 	id, err := tq.OnlyID(ctx)
 	if err != nil {
 		panic(err)
@@ -178,7 +179,7 @@ func (tq *ThingQuery) AllX(ctx context.Context) []*Thing {
 }
 
 // IDs executes the query and returns a list of Thing IDs.
-func (tq *ThingQuery) IDs(ctx context.Context) (ids []int, err error) {
+func (tq *ThingQuery) IDs(ctx context.Context) (ids []uuid.UUID, err error) {
 	if tq.ctx.Unique == nil && tq.path != nil {
 		tq.Unique(true)
 	}
@@ -190,7 +191,7 @@ func (tq *ThingQuery) IDs(ctx context.Context) (ids []int, err error) {
 }
 
 // IDsX is like IDs, but panics if an error occurs.
-func (tq *ThingQuery) IDsX(ctx context.Context) []int {
+func (tq *ThingQuery) IDsX(ctx context.Context) []uuid.UUID {
 	ids, err := tq.IDs(ctx)
 	if err != nil {
 		panic(err)
@@ -262,12 +263,12 @@ func (tq *ThingQuery) Clone() *ThingQuery {
 // Example:
 //
 //	var v []struct {
-//		Name string `json:"name,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Thing.Query().
-//		GroupBy(thing.FieldName).
+//		GroupBy(thing.FieldCreatedAt).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 func (tq *ThingQuery) GroupBy(field string, fields ...string) *ThingGroupBy {
@@ -285,11 +286,11 @@ func (tq *ThingQuery) GroupBy(field string, fields ...string) *ThingGroupBy {
 // Example:
 //
 //	var v []struct {
-//		Name string `json:"name,omitempty"`
+//		CreatedAt time.Time `json:"created_at,omitempty"`
 //	}
 //
 //	client.Thing.Query().
-//		Select(thing.FieldName).
+//		Select(thing.FieldCreatedAt).
 //		Scan(ctx, &v)
 func (tq *ThingQuery) Select(fields ...string) *ThingSelect {
 	tq.ctx.Fields = append(tq.ctx.Fields, fields...)
@@ -365,7 +366,7 @@ func (tq *ThingQuery) sqlCount(ctx context.Context) (int, error) {
 }
 
 func (tq *ThingQuery) querySpec() *sqlgraph.QuerySpec {
-	_spec := sqlgraph.NewQuerySpec(thing.Table, thing.Columns, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeInt))
+	_spec := sqlgraph.NewQuerySpec(thing.Table, thing.Columns, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeUUID))
 	_spec.From = tq.sql
 	if unique := tq.ctx.Unique; unique != nil {
 		_spec.Unique = *unique
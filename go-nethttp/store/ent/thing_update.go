@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -27,6 +28,12 @@ func (tu *ThingUpdate) Where(ps ...predicate.Thing) *ThingUpdate {
 	return tu
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (tu *ThingUpdate) SetUpdatedAt(t time.Time) *ThingUpdate {
+	tu.mutation.SetUpdatedAt(t)
+	return tu
+}
+
 // SetName sets the "name" field.
 func (tu *ThingUpdate) SetName(s string) *ThingUpdate {
 	tu.mutation.SetName(s)
@@ -41,6 +48,32 @@ func (tu *ThingUpdate) SetNillableName(s *string) *ThingUpdate {
 	return tu
 }
 
+// SetDescription sets the "description" field.
+func (tu *ThingUpdate) SetDescription(s string) *ThingUpdate {
+	tu.mutation.SetDescription(s)
+	return tu
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tu *ThingUpdate) SetNillableDescription(s *string) *ThingUpdate {
+	if s != nil {
+		tu.SetDescription(*s)
+	}
+	return tu
+}
+
+// SetAttributes sets the "attributes" field.
+func (tu *ThingUpdate) SetAttributes(m map[string]interface{}) *ThingUpdate {
+	tu.mutation.SetAttributes(m)
+	return tu
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (tu *ThingUpdate) ClearAttributes() *ThingUpdate {
+	tu.mutation.ClearAttributes()
+	return tu
+}
+
 // Mutation returns the ThingMutation object of the builder.
 func (tu *ThingUpdate) Mutation() *ThingMutation {
 	return tu.mutation
@@ -48,6 +81,7 @@ func (tu *ThingUpdate) Mutation() *ThingMutation {
 
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (tu *ThingUpdate) Save(ctx context.Context) (int, error) {
+	tu.defaults()
 	return withHooks(ctx, tu.sqlSave, tu.mutation, tu.hooks)
 }
 
@@ -73,8 +107,16 @@ func (tu *ThingUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (tu *ThingUpdate) defaults() {
+	if _, ok := tu.mutation.UpdatedAt(); !ok {
+		v := thing.UpdateDefaultUpdatedAt()
+		tu.mutation.SetUpdatedAt(v)
+	}
+}
+
 func (tu *ThingUpdate) sqlSave(ctx context.Context) (n int, err error) {
-	_spec := sqlgraph.NewUpdateSpec(thing.Table, thing.Columns, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeInt))
+	_spec := sqlgraph.NewUpdateSpec(thing.Table, thing.Columns, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeUUID))
 	if ps := tu.mutation.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
 			for i := range ps {
@@ -82,9 +124,21 @@ func (tu *ThingUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := tu.mutation.UpdatedAt(); ok {
+		_spec.SetField(thing.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if value, ok := tu.mutation.Name(); ok {
 		_spec.SetField(thing.FieldName, field.TypeString, value)
 	}
+	if value, ok := tu.mutation.Description(); ok {
+		_spec.SetField(thing.FieldDescription, field.TypeString, value)
+	}
+	if value, ok := tu.mutation.Attributes(); ok {
+		_spec.SetField(thing.FieldAttributes, field.TypeJSON, value)
+	}
+	if tu.mutation.AttributesCleared() {
+		_spec.ClearField(thing.FieldAttributes, field.TypeJSON)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, tu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{thing.Label}
@@ -105,6 +159,12 @@ type ThingUpdateOne struct {
 	mutation *ThingMutation
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (tuo *ThingUpdateOne) SetUpdatedAt(t time.Time) *ThingUpdateOne {
+	tuo.mutation.SetUpdatedAt(t)
+	return tuo
+}
+
 // SetName sets the "name" field.
 func (tuo *ThingUpdateOne) SetName(s string) *ThingUpdateOne {
 	tuo.mutation.SetName(s)
@@ -119,6 +179,32 @@ func (tuo *ThingUpdateOne) SetNillableName(s *string) *ThingUpdateOne {
 	return tuo
 }
 
+// SetDescription sets the "description" field.
+func (tuo *ThingUpdateOne) SetDescription(s string) *ThingUpdateOne {
+	tuo.mutation.SetDescription(s)
+	return tuo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tuo *ThingUpdateOne) SetNillableDescription(s *string) *ThingUpdateOne {
+	if s != nil {
+		tuo.SetDescription(*s)
+	}
+	return tuo
+}
+
+// SetAttributes sets the "attributes" field.
+func (tuo *ThingUpdateOne) SetAttributes(m map[string]interface{}) *ThingUpdateOne {
+	tuo.mutation.SetAttributes(m)
+	return tuo
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (tuo *ThingUpdateOne) ClearAttributes() *ThingUpdateOne {
+	tuo.mutation.ClearAttributes()
+	return tuo
+}
+
 // Mutation returns the ThingMutation object of the builder.
 func (tuo *ThingUpdateOne) Mutation() *ThingMutation {
 	return tuo.mutation
@@ -139,6 +225,7 @@ func (tuo *ThingUpdateOne) Select(field string, fields ...string) *ThingUpdateOn
 
 // Save executes the query and returns the updated Thing entity.
 func (tuo *ThingUpdateOne) Save(ctx context.Context) (*Thing, error) {
+	tuo.defaults()
 	return withHooks(ctx, tuo.sqlSave, tuo.mutation, tuo.hooks)
 }
 
@@ -164,8 +251,16 @@ func (tuo *ThingUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (tuo *ThingUpdateOne) defaults() {
+	if _, ok := tuo.mutation.UpdatedAt(); !ok {
+		v := thing.UpdateDefaultUpdatedAt()
+		tuo.mutation.SetUpdatedAt(v)
+	}
+}
+
 func (tuo *ThingUpdateOne) sqlSave(ctx context.Context) (_node *Thing, err error) {
-	_spec := sqlgraph.NewUpdateSpec(thing.Table, thing.Columns, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeInt))
+	_spec := sqlgraph.NewUpdateSpec(thing.Table, thing.Columns, sqlgraph.NewFieldSpec(thing.FieldID, field.TypeUUID))
 	id, ok := tuo.mutation.ID()
 	if !ok {
 		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Thing.id" for update`)}
@@ -190,9 +285,21 @@ func (tuo *ThingUpdateOne) sqlSave(ctx context.Context) (_node *Thing, err error
 			}
 		}
 	}
+	if value, ok := tuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(thing.FieldUpdatedAt, field.TypeTime, value)
+	}
 	if value, ok := tuo.mutation.Name(); ok {
 		_spec.SetField(thing.FieldName, field.TypeString, value)
 	}
+	if value, ok := tuo.mutation.Description(); ok {
+		_spec.SetField(thing.FieldDescription, field.TypeString, value)
+	}
+	if value, ok := tuo.mutation.Attributes(); ok {
+		_spec.SetField(thing.FieldAttributes, field.TypeJSON, value)
+	}
+	if tuo.mutation.AttributesCleared() {
+		_spec.ClearField(thing.FieldAttributes, field.TypeJSON)
+	}
 	_node = &Thing{config: tuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/keith-cullen/microservice/store/ent"
+	"github.com/keith-cullen/microservice/store/ent/thing"
+	"github.com/keith-cullen/microservice/store/migrate"
+)
+
+// SQLite databases created by earlier versions have a things table with integer IDs and only a name
+// These functions move those things into the current schema in two steps around the migrations:
+// renameLegacyThings moves the old table out of the way and copyLegacyThings copies its rows back
+// If the copy is interrupted then it is resumed the next time the migrations are applied
+
+const (
+	legacyThingsTable = "legacy_things"
+)
+
+// renameLegacyThings renames the things table if it has integer IDs
+func renameLegacyThings(ctx context.Context, db *sql.DB) error {
+	var idType string
+	err := db.QueryRowContext(ctx, "SELECT type FROM pragma_table_info(?) WHERE name = ?", thing.Table, thing.FieldID).Scan(&idType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to inspect things table: %w", err)
+	}
+	if !strings.EqualFold(idType, "integer") {
+		return nil
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", thing.Table, legacyThingsTable)); err != nil {
+		return fmt.Errorf("failed to rename legacy things table: %w", err)
+	}
+	slog.InfoContext(ctx, "renamed legacy things table", "table", legacyThingsTable)
+	return nil
+}
+
+// copyLegacyThings copies the rows of the renamed legacy things table into the current things table and then drops it
+// Things with duplicate names are merged into the oldest one
+func copyLegacyThings(ctx context.Context, db *sql.DB, dialectName string, client *ent.Client) error {
+	exists, err := migrate.TableExists(ctx, db, dialectName, legacyThingsTable)
+	if err != nil || !exists {
+		return err
+	}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT name FROM %s ORDER BY id", legacyThingsTable))
+	if err != nil {
+		return fmt.Errorf("failed to read legacy things: %w", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read legacy things: %w", err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read legacy things: %w", err)
+	}
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to copy legacy things: %w", err)
+	}
+	copied := 0
+	for _, name := range names {
+		exists, err := tx.Thing.Query().Where(thing.Name(name)).Exist(ctx)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to copy legacy things: %w", err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := tx.Thing.Create().SetName(name).Save(ctx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to copy legacy things: %w", err)
+		}
+		copied++
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to copy legacy things: %w", err)
+	}
+	// things that were already copied are skipped if this fails and the copy is repeated
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", legacyThingsTable)); err != nil {
+		return fmt.Errorf("failed to drop legacy things table: %w", err)
+	}
+	slog.InfoContext(ctx, "copied legacy things", "copied", copied, "total", len(names))
+	return nil
+}
@@ -0,0 +1,2 @@
+-- reverse: create "things" table
+DROP TABLE `things`;
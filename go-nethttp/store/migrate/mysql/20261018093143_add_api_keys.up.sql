@@ -0,0 +1,2 @@
+-- create "api_keys" table
+CREATE TABLE `api_keys` (`id` char(36) NOT NULL, `created_at` timestamp NOT NULL, `updated_at` timestamp NOT NULL, `hash` varchar(255) NOT NULL, `owner` varchar(255) NOT NULL, `scopes` json NOT NULL, `expires_at` timestamp NULL, `last_used_at` timestamp NULL, `revoked_at` timestamp NULL, PRIMARY KEY (`id`), UNIQUE INDEX `apikey_hash` (`hash`)) CHARSET utf8mb4 COLLATE utf8mb4_bin;
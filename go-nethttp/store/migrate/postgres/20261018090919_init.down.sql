@@ -0,0 +1,4 @@
+-- reverse: create index "thing_name" to table: "things"
+DROP INDEX "thing_name";
+-- reverse: create "things" table
+DROP TABLE "things";
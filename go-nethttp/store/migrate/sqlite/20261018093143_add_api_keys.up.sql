@@ -0,0 +1,4 @@
+-- create "api_keys" table
+CREATE TABLE `api_keys` (`id` uuid NOT NULL, `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, `hash` text NOT NULL, `owner` text NOT NULL, `scopes` json NOT NULL, `expires_at` datetime NULL, `last_used_at` datetime NULL, `revoked_at` datetime NULL, PRIMARY KEY (`id`));
+-- create index "apikey_hash" to table: "api_keys"
+CREATE UNIQUE INDEX `apikey_hash` ON `api_keys` (`hash`);
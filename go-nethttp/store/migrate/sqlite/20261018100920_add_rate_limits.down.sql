@@ -0,0 +1,4 @@
+-- reverse: create index "ratelimit_tat" to table: "rate_limits"
+DROP INDEX `ratelimit_tat`;
+-- reverse: create "rate_limits" table
+DROP TABLE `rate_limits`;
@@ -0,0 +1,4 @@
+-- create "rate_limits" table
+CREATE TABLE `rate_limits` (`id` text NOT NULL, `tat` integer NOT NULL, PRIMARY KEY (`id`));
+-- create index "ratelimit_tat" to table: "rate_limits"
+CREATE INDEX `ratelimit_tat` ON `rate_limits` (`tat`);
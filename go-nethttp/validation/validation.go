@@ -0,0 +1,182 @@
+// Package validation checks requests and responses against the OpenAPI spec
+//
+// Requests that violate the spec are rejected before they reach the handlers, and in strict mode
+// responses that violate it are replaced by server errors so that drift between the servers and the spec is caught
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/google/uuid"
+	"github.com/keith-cullen/microservice/problem"
+)
+
+const (
+	maxBodyBytes = 1 << 20 // maxBodyBytes bounds the request bodies that are read to be validated
+	bodyField    = "body"  // bodyField is the field of the errors of a request body as a whole
+)
+
+func init() {
+	// the spec's path parameters are UUIDs, which kin-openapi does not check by default
+	openapi3.DefineStringFormatCallback("uuid", func(s string) error {
+		_, err := uuid.Parse(s)
+		return err
+	})
+	// the errors are logged and sent to clients without the schema and the value that failed
+	openapi3.SchemaErrorDetailsDisabled = true
+}
+
+// Validator validates requests, and responses in strict mode, against a spec
+type Validator struct {
+	router  routers.Router
+	options *openapi3filter.Options
+	strict  bool
+}
+
+// New creates a validator for a spec, strict validators also validate the responses
+func New(spec *openapi3.T, strict bool) (*Validator, error) {
+	// the spec has no servers since it is served from any address, so its paths are matched from the root
+	spec.Servers = nil
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+	return &Validator{
+		router: router,
+		options: &openapi3filter.Options{
+			MultiError: true,
+			// requests are authenticated by the middleware that runs before the validator
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			// the handlers apply the defaults themselves, so the requests are not changed
+			SkipSettingDefaults: true,
+		},
+		strict: strict,
+	}, nil
+}
+
+// Middleware rejects requests that violate the spec with a bad request that lists the invalid fields
+// Requests for paths or methods that are not in the spec are passed on so that they get the usual errors
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    v.options,
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			slog.InfoContext(r.Context(), "request does not match the spec", "error", err)
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				problem.New(r, http.StatusRequestEntityTooLarge, fmt.Sprintf("the request body is larger than %d bytes", maxBytesErr.Limit)).Write(w)
+				return
+			}
+			problem.Invalid(r, fieldErrors(err)...).Write(w)
+			return
+		}
+		if !v.strict {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.Header().Get("Content-Type") == "" && rec.body.Len() > 0 {
+			// the content type that net/http would sniff is what the client gets
+			rec.Header().Set("Content-Type", http.DetectContentType(rec.body.Bytes()))
+		}
+		if err := v.validateResponse(input, rec); err != nil {
+			// the mismatch is only logged since the details of server errors are not sent to clients
+			slog.ErrorContext(r.Context(), "response does not match the spec", "status", rec.status, "error", err)
+			problem.New(r, http.StatusInternalServerError, "").Write(w)
+			return
+		}
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.body.Bytes())
+	})
+}
+
+// validateResponse validates a recorded response against the spec of its request
+func (v *Validator) validateResponse(input *openapi3filter.RequestValidationInput, rec *recorder) error {
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.status,
+		Header:                 rec.Header(),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	}
+	responseInput.SetBodyBytes(rec.body.Bytes())
+	return openapi3filter.ValidateResponse(input.Request.Context(), responseInput)
+}
+
+// fieldErrors returns the invalid fields of a request that failed validation
+func fieldErrors(err error) []problem.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var errs []problem.FieldError
+		for _, err := range e {
+			errs = append(errs, fieldErrors(err)...)
+		}
+		return errs
+	case *openapi3filter.RequestError:
+		field := bodyField
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+		return reasons(field, e.Reason, e.Err)
+	default:
+		return []problem.FieldError{{Field: bodyField, Detail: err.Error()}}
+	}
+}
+
+// reasons returns the errors of a parameter or request body
+// The errors of the fields of a request body are given by their JSON path, e.g. attributes.size
+func reasons(field, reason string, err error) []problem.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var errs []problem.FieldError
+		for _, err := range e {
+			errs = append(errs, reasons(field, reason, err)...)
+		}
+		return errs
+	case *openapi3.SchemaError:
+		if path := e.JSONPointer(); field == bodyField && len(path) > 0 {
+			field = strings.Join(path, ".")
+		}
+		return []problem.FieldError{{Field: field, Detail: e.Reason}}
+	}
+	if reason == "" && err != nil {
+		reason = err.Error()
+	}
+	return []problem.FieldError{{Field: field, Detail: reason}}
+}
+
+// recorder holds a response until it has been validated
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}